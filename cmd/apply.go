@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+var applyFile string
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <plan.yaml>",
+	Short: "Create an epic tree described in a plan file",
+	Long: `Create an epic with its stories and tasks from a YAML plan.
+
+Every node has a key that is unique in the plan. Other nodes refer to it in
+depends_on, and it is stored in the issue body as a hidden comment, so applying
+the same plan again only creates what is missing. Existing issues keep their
+body; their Team and Priority are set to the plan's values, and missing links
+and dependencies are added.
+
+Issues are created in dependency order in the default repository, linked to
+their parent, set as blocked by their dependencies and then transferred to
+their team's repository (unless no_transfer is set). Template fields of all
+new issues are validated before anything is created.
+
+Nodes without a type are epic at the root, user_story below it and task further
+down. Nodes without a title get one from their template's title pattern (see
+'issue create --help').
+
+Plan format:
+  epic:
+    key: auth
+    title: User authentication
+    team: Backend
+    priority: High
+    fields:
+      description: Login and signup for all apps
+    children:
+      - key: login
+        title: As a user I want to log in
+        fields:
+          description: ...
+        children:
+          - key: login-api
+            title: Implement login endpoint
+            team: Backend
+            depends_on: [signup-api]
+            fields:
+              description: ...
+
+Examples:
+  # Create the epic tree, or complete it after a partial run
+  gh project-management apply -f plan.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	plan, err := project.LoadPlan(applyFile)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Failed steps are reported with the summary below
+	service := project.New(client, cfg, func(event project.Event) {
+		switch {
+		case event.Err != nil:
+		case event.Step == project.StepCreate:
+			fmt.Printf("✓ %s\n", event.Message)
+		default:
+			fmt.Printf("  ✓ %s %s\n", event.Issue, event.Message)
+		}
+	})
+
+	fmt.Printf("🚀 Applying %s to %s (%s #%s)...\n\n", applyFile, cfg.Name, cfg.ProjectName, cfg.ProjectID)
+
+	result, err := service.Apply(ctx, plan)
+	if result != nil && len(result.Issues) > 0 {
+		printApplyResult(result)
+	}
+	if err != nil {
+		if result != nil && len(result.Issues) > 0 {
+			fmt.Printf("\n💡 Fix the problem and run apply again to create the remaining issues\n")
+		}
+		return err
+	}
+
+	warnings := result.AllWarnings()
+	for _, warning := range warnings {
+		fmt.Printf("⚠️  Warning: %v\n", warning)
+		if hint := gh.Hint(warning); hint != "" {
+			fmt.Printf("💡 %s\n", hint)
+		}
+	}
+
+	created := result.Created()
+	fmt.Printf("\n✓ Applied plan: %d created, %d already existed\n", created, len(result.Issues)-created)
+	if len(warnings) > 0 {
+		fmt.Printf("💡 Run apply again to retry the steps that failed\n")
+	}
+	return nil
+}
+
+// printApplyResult prints where each plan key ended up
+func printApplyResult(result *project.ApplyResult) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "KEY\tTYPE\tISSUE\tSTATUS\tTITLE")
+	for _, applied := range result.Issues {
+		status := "exists"
+		if applied.Created {
+			status = "created"
+		}
+		if applied.Transferred {
+			status += ", transferred"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", applied.Key, applied.Type, applied.Ref, status, truncate(applied.Title, 50))
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+func init() {
+	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "Plan file (YAML)")
+	applyCmd.MarkFlagRequired("file")
+	rootCmd.AddCommand(applyCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh/cache"
+	contextPkg "github.com/Zytera/gh-project-management/pkg/context"
+	"github.com/spf13/cobra"
+)
+
+var cacheClearAll bool
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the project metadata cache",
+	Long: `Manage the on-disk cache of project metadata.
+
+Project node IDs, project fields and organization issue types are cached per
+context under ~/.config/gh-project-management/cache. Node IDs are kept for
+7 days, fields and issue types for 1 hour. Entries are refreshed automatically
+when a field, option or issue type is not found in the cache, and when the
+extension creates fields, options or issue types itself.`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [context-name]",
+	Short: "Clear the metadata cache",
+	Long: `Clear the cached metadata of a context (the current context by default).
+
+Examples:
+  # Clear the cache of the current context
+  gh project-management cache clear
+
+  # Clear the cache of a specific context
+  gh project-management cache clear mycontext
+
+  # Clear the cache of every context
+  gh project-management cache clear --all`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCacheClear,
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	var names []string
+	switch {
+	case cacheClearAll:
+		globalConfig, err := config.LoadGlobal()
+		if err != nil {
+			return err
+		}
+		for name := range globalConfig.Contexts {
+			names = append(names, name)
+		}
+	case len(args) == 1:
+		globalConfig, err := config.LoadGlobal()
+		if err != nil {
+			return err
+		}
+		if _, ok := globalConfig.Contexts[args[0]]; !ok {
+			return fmt.Errorf("context '%s' not found", args[0])
+		}
+		names = []string{args[0]}
+	default:
+		_, name, err := contextPkg.GetCurrentContext()
+		if err != nil {
+			return err
+		}
+		names = []string{name}
+	}
+
+	for _, name := range names {
+		cachePath, err := config.GetCachePath(name)
+		if err != nil {
+			return err
+		}
+		if err := cache.Clear(cachePath); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Cleared cache for context '%s'\n", name)
+	}
+
+	return nil
+}
+
+func init() {
+	cacheClearCmd.Flags().BoolVar(&cacheClearAll, "all", false, "Clear the cache of every context")
+
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
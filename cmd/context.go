@@ -14,6 +14,7 @@ import (
 
 var (
 	// Flags for context add
+	contextHost        string
 	contextOwnerType   string
 	contextOwner       string
 	contextProjectID   string
@@ -66,6 +67,16 @@ var contextAddCmd = &cobra.Command{
     --default-repo project-management \
     --team-repos Backend=backend,App=mobile-app,Web=web-app,Auth=auth
 
+  # With flags (GitHub Enterprise Server)
+  gh project-management context add enterprise \
+    --host github.example.com \
+    --owner-type org \
+    --owner platform \
+    --project-id 7 \
+    --project-name "Platform" \
+    --default-repo planning \
+    --team-repos Backend=backend
+
   # With flags (personal project)
   gh project-management context add myproject \
     --owner-type user \
@@ -127,6 +138,7 @@ func init() {
 	contextCmd.AddCommand(contextUpdateCmd)
 
 	// Add flags for context add
+	contextAddCmd.Flags().StringVar(&contextHost, "host", "", "GitHub host, for GitHub Enterprise Server (default: github.com or GH_HOST)")
 	contextAddCmd.Flags().StringVar(&contextOwnerType, "owner-type", "", "Owner type: 'user' or 'org'")
 	contextAddCmd.Flags().StringVar(&contextOwner, "owner", "", "Owner name (organization or username)")
 	contextAddCmd.Flags().StringVar(&contextProjectID, "project-id", "", "Project ID")
@@ -135,6 +147,7 @@ func init() {
 	contextAddCmd.Flags().StringSliceVar(&contextTeamRepos, "team-repos", []string{}, "Team repositories in format 'team=repo' (e.g., Backend=backend,App=mobile-app)")
 
 	// Add flags for context update
+	contextUpdateCmd.Flags().StringVar(&contextHost, "host", "", "New GitHub host (empty for the default host)")
 	contextUpdateCmd.Flags().StringVar(&contextProjectID, "project-id", "", "New project ID")
 	contextUpdateCmd.Flags().StringVar(&contextProjectName, "project-name", "", "New project name")
 	contextUpdateCmd.Flags().StringVar(&contextDefaultRepo, "default-repo", "", "New default repository")
@@ -160,6 +173,14 @@ func parseTeamRepos(teamRepoSlice []string) (map[string]string, error) {
 	return teamRepos, nil
 }
 
+// displayHost returns the host shown for a context, "default" when none is set
+func displayHost(host string) string {
+	if host == "" {
+		return "default"
+	}
+	return host
+}
+
 func runContextList(cmd *cobra.Command, args []string) error {
 	globalConfig, err := contextPkg.ListContexts()
 	if err != nil {
@@ -175,7 +196,7 @@ func runContextList(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "CURRENT\tNAME\tOWNER\tPROJECT\tDEFAULT REPO")
+	fmt.Fprintln(w, "CURRENT\tNAME\tHOST\tOWNER\tPROJECT\tDEFAULT REPO")
 
 	for name, ctx := range globalConfig.Contexts {
 		current := " "
@@ -186,7 +207,7 @@ func runContextList(cmd *cobra.Command, args []string) error {
 		if ctx.OwnerType == "user" {
 			ownerDisplay = ctx.Owner + " (personal)"
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s (#%s)\t%s\n", current, name, ownerDisplay, ctx.ProjectName, ctx.ProjectID, ctx.DefaultRepo)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s (#%s)\t%s\n", current, name, displayHost(ctx.Host), ownerDisplay, ctx.ProjectName, ctx.ProjectID, ctx.DefaultRepo)
 	}
 
 	w.Flush()
@@ -205,6 +226,7 @@ func runContextCurrent(cmd *cobra.Command, args []string) error {
 	if ctx.OwnerType == "user" {
 		ownerLabel = "Owner (personal)"
 	}
+	fmt.Printf("  Host:            %s\n", displayHost(ctx.Host))
 	fmt.Printf("  %s:    %s\n", ownerLabel, ctx.Owner)
 	fmt.Printf("  Project:         %s (#%s)\n", ctx.ProjectName, ctx.ProjectID)
 	fmt.Printf("  Default repo:    %s\n", ctx.DefaultRepo)
@@ -255,6 +277,7 @@ func runContextAdd(cmd *cobra.Command, args []string) error {
 		// Non-interactive mode with flags
 		params := contextPkg.AddContextParams{
 			Name:        contextName,
+			Host:        contextHost,
 			OwnerType:   ownerType,
 			Owner:       contextOwner,
 			ProjectID:   contextProjectID,
@@ -263,7 +286,12 @@ func runContextAdd(cmd *cobra.Command, args []string) error {
 			TeamRepos:   teamRepos,
 		}
 
-		if err := contextPkg.AddContext(params); err != nil {
+		client, err := newHostClient(cmd.Context(), contextHost)
+		if err != nil {
+			return err
+		}
+
+		if err := contextPkg.AddContext(client, params); err != nil {
 			return err
 		}
 
@@ -272,13 +300,19 @@ func runContextAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Interactive mode
-	ctx, err := contextTUI.CollectContextConfiguration()
+	client, err := newHostClient(cmd.Context(), contextHost)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := contextTUI.CollectContextConfiguration(client)
 	if err != nil {
 		return err
 	}
 
 	params := contextPkg.AddContextParams{
 		Name:        contextName,
+		Host:        contextHost,
 		OwnerType:   ctx.OwnerType,
 		Owner:       ctx.Owner,
 		ProjectID:   ctx.ProjectID,
@@ -287,7 +321,7 @@ func runContextAdd(cmd *cobra.Command, args []string) error {
 		TeamRepos:   ctx.TeamRepos,
 	}
 
-	if err := contextPkg.AddContext(params); err != nil {
+	if err := contextPkg.AddContext(client, params); err != nil {
 		return err
 	}
 
@@ -338,12 +372,13 @@ func runContextUpdate(cmd *cobra.Command, args []string) error {
 	contextName := args[0]
 
 	// Check if at least one flag was provided
+	hasHost := cmd.Flags().Changed("host")
 	hasProjectID := cmd.Flags().Changed("project-id")
 	hasProjectName := cmd.Flags().Changed("project-name")
 	hasDefaultRepo := cmd.Flags().Changed("default-repo")
 	hasTeamRepos := cmd.Flags().Changed("team-repos")
 
-	if !hasProjectID && !hasProjectName && !hasDefaultRepo && !hasTeamRepos {
+	if !hasHost && !hasProjectID && !hasProjectName && !hasDefaultRepo && !hasTeamRepos {
 		return fmt.Errorf("at least one field must be specified to update")
 	}
 
@@ -353,6 +388,10 @@ func runContextUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Set optional parameters
+	if hasHost {
+		params.Host = &contextHost
+	}
+
 	if hasProjectID {
 		params.ProjectID = &contextProjectID
 	}
@@ -373,7 +412,22 @@ func runContextUpdate(cmd *cobra.Command, args []string) error {
 		params.TeamRepos = teamRepos
 	}
 
-	if err := contextPkg.UpdateContext(params); err != nil {
+	// Talk to the host the context will point at after the update
+	host := contextHost
+	if !hasHost {
+		existing, err := contextPkg.ListContexts()
+		if err != nil {
+			return err
+		}
+		host = existing.Contexts[contextName].Host
+	}
+
+	client, err := newHostClient(cmd.Context(), host)
+	if err != nil {
+		return err
+	}
+
+	if err := contextPkg.UpdateContext(client, params); err != nil {
 		return err
 	}
 
@@ -381,6 +435,9 @@ func runContextUpdate(cmd *cobra.Command, args []string) error {
 
 	// Show what was updated
 	fmt.Println("\nUpdated fields:")
+	if hasHost {
+		fmt.Printf("  Host: %s\n", displayHost(contextHost))
+	}
 	if hasProjectID {
 		fmt.Printf("  Project ID: %s\n", contextProjectID)
 	}
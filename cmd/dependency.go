@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
 
 	"github.com/Zytera/gh-project-management/internal/config"
@@ -27,6 +27,7 @@ Issue references can be specified as:
   - #123 (issue in configured default repo)
   - 123 (issue in configured default repo)
   - owner/repo#123 (issue in a specific repository)
+  - https://<host>/owner/repo/issues/123 (issue URL, including GitHub Enterprise Server)
 
 Examples:
   # Issue #46 is blocked by issue #45 (both in default repo)
@@ -42,7 +43,7 @@ Examples:
 }
 
 func runDependencyAdd(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -50,11 +51,16 @@ func runDependencyAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	blockedIssueRef := args[0]
 	blockingIssueRefs := args[1:]
 
 	// Parse blocked issue reference
-	blockedOwner, blockedRepo, blockedNumber, err := gh.ParseIssueReference(blockedIssueRef, cfg.Owner, cfg.DefaultRepo)
+	blockedOwner, blockedRepo, blockedNumber, err := gh.ParseIssueReference(blockedIssueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 	if err != nil {
 		return fmt.Errorf("invalid blocked issue reference: %w", err)
 	}
@@ -68,7 +74,7 @@ func runDependencyAdd(cmd *cobra.Command, args []string) error {
 	// Add each blocking issue as a dependency
 	for _, blockingIssueRef := range blockingIssueRefs {
 		// Parse blocking issue reference
-		blockingOwner, blockingRepo, blockingNumber, err := gh.ParseIssueReference(blockingIssueRef, cfg.Owner, cfg.DefaultRepo)
+		blockingOwner, blockingRepo, blockingNumber, err := gh.ParseIssueReference(blockingIssueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 		if err != nil {
 			fmt.Printf("⚠️  Warning: Invalid blocking issue reference '%s': %v\n", blockingIssueRef, err)
 			continue
@@ -81,7 +87,11 @@ func runDependencyAdd(cmd *cobra.Command, args []string) error {
 		}
 
 		// Add the dependency
-		err = gh.AddBlockedBy(ctx, blockedOwner, blockedRepo, blockedNumber, blockingNumber)
+		err = client.AddBlockedBy(ctx, blockedOwner, blockedRepo, blockedNumber, blockingNumber)
+		if errors.Is(err, gh.ErrMissingScope) || errors.Is(err, gh.ErrPermissionDenied) {
+			// Every remaining dependency would fail the same way
+			return fmt.Errorf("failed to add dependency on #%d: %w", blockingNumber, err)
+		}
 		if err != nil {
 			fmt.Printf("⚠️  Warning: Failed to add dependency on #%d: %v\n", blockingNumber, err)
 			continue
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/pkg/doctor"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check authentication, project access and configuration",
+	Long: `Run preflight checks for the current context:
+
+  - The gh token has the project, read:org and repo scopes
+  - The context's project number resolves
+  - The default repository and every team repository exist and are writable
+  - The Team and Priority fields exist with the expected options
+  - Issue types are enabled for the organization
+
+Cached metadata is not used, so the checks reflect the current state on GitHub.
+
+Examples:
+  gh project-management doctor`,
+	Args:         cobra.NoArgs,
+	RunE:         runDoctor,
+	SilenceUsage: true,
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("✗ Configuration: %v\n", err)
+		return fmt.Errorf("doctor found problems with the configuration")
+	}
+	fmt.Printf("✓ Configuration: context '%s'\n", cfg.Name)
+
+	client, err := newHostClient(ctx, cfg.Host)
+	if err != nil {
+		return err
+	}
+
+	report := doctor.Run(ctx, client, cfg)
+	for _, check := range report.Checks {
+		switch check.Status {
+		case doctor.StatusOK:
+			fmt.Printf("✓ %s: %s\n", check.Name, check.Detail)
+		case doctor.StatusWarn:
+			fmt.Printf("⚠️  %s: %s\n", check.Name, check.Detail)
+		case doctor.StatusFail:
+			fmt.Printf("✗ %s: %s\n", check.Name, check.Detail)
+		}
+		if check.Hint != "" && check.Status != doctor.StatusOK {
+			fmt.Printf("   💡 %s\n", check.Hint)
+		}
+	}
+
+	if failed := report.Failed(); failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+
+	fmt.Println("\n✓ All checks passed")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
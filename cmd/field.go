@@ -1,27 +1,19 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/Zytera/gh-project-management/internal/config"
 	"github.com/Zytera/gh-project-management/internal/gh"
-	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/spf13/cobra"
 )
 
 var (
-	teamValue       string
-	priorityValue   string
-	typeValue       string
-	noTransfer      bool
-	teamRepoMapping = map[string]string{
-		"Backend": "backend",
-		"App":     "mobile-app",
-		"Web":     "web-app",
-		"Auth":    "auth",
-	}
+	teamValue     string
+	priorityValue string
+	typeValue     string
+	noTransfer    bool
 )
 
 var fieldCmd = &cobra.Command{
@@ -39,7 +31,7 @@ var fieldSetCmd = &cobra.Command{
 	Long: `Set custom fields (Team, Priority, Type) for an issue in the project.
 
 Available fields:
-  --team         Team responsible (Backend, App, Web, Auth) - auto-transfers to team repo
+  --team         Team responsible (from team_repos) - auto-transfers to team repo
   --priority     Priority level (Critical, High, Medium, Low)
   --type         Issue type (Epic, User Story, Story, Task, Bug, Feature)
   --no-transfer  Prevent automatic transfer when Team field is set
@@ -58,7 +50,7 @@ Examples:
 }
 
 func runFieldSet(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -66,9 +58,14 @@ func runFieldSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Parse issue reference
 	issueRef := args[0]
-	owner, repo, issueNumber, err := gh.ParseIssueReference(issueRef, cfg.Owner, cfg.DefaultRepo)
+	owner, repo, issueNumber, err := gh.ParseIssueReference(issueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 	if err != nil {
 		return fmt.Errorf("invalid issue reference: %w", err)
 	}
@@ -87,59 +84,36 @@ func runFieldSet(cmd *cobra.Command, args []string) error {
 	// Get project node ID
 	var projectNodeID string
 	if cfg.OwnerType == config.OwnerTypeOrg {
-		projectNodeID, err = gh.GetProjectNodeID(ctx, cfg.Owner, projectNumber)
+		projectNodeID, err = client.GetProjectNodeID(ctx, cfg.Owner, projectNumber)
 	} else {
-		projectNodeID, err = gh.GetUserProjectNodeID(ctx, projectNumber)
+		projectNodeID, err = client.GetUserProjectNodeID(ctx, projectNumber)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to get project node ID: %w", err)
 	}
 
 	// Get issue node ID
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
-	issueNodeID, err := gh.GetIssueNodeID(ctx, *client, owner, repo, issueNumber)
+	issueNodeID, err := client.GetIssueNodeID(ctx, owner, repo, issueNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get issue node ID: %w", err)
 	}
 
-	// Get project item ID for the issue
-	projectItemID, err := gh.GetProjectItemID(ctx, projectNodeID, issueNodeID)
+	// Get project item ID for the issue (adds it to the project if missing)
+	projectItemID, err := gh.EnsureProjectItem(ctx, client, projectNodeID, issueNodeID)
 	if err != nil {
 		return fmt.Errorf("failed to get project item ID: %w", err)
 	}
 
-	// Get project fields
-	fields, err := gh.GetProjectFields(ctx, projectNodeID)
-	if err != nil {
-		return fmt.Errorf("failed to get project fields: %w", err)
-	}
-
 	fmt.Printf("Setting custom fields for issue #%d...\n", issueNumber)
 
 	// Set Team field if specified
 	if teamValue != "" {
-		teamField := gh.FindFieldByName(fields, "Team")
-		if teamField == nil {
-			return fmt.Errorf("Team field not found in project")
-		}
-
-		// Find the option ID for the team value
-		var optionID string
-		for _, opt := range teamField.Options {
-			if opt.Name == teamValue {
-				optionID = opt.ID
-				break
-			}
-		}
-		if optionID == "" {
-			return fmt.Errorf("team value '%s' not found in Team field options", teamValue)
+		teamField, optionID, err := gh.FindFieldOption(ctx, client, projectNodeID, "Team", teamValue)
+		if err != nil {
+			return err
 		}
 
-		err = gh.UpdateProjectItemField(ctx, projectNodeID, projectItemID, teamField.ID, optionID)
+		err = client.UpdateProjectItemField(ctx, projectNodeID, projectItemID, teamField.ID, optionID)
 		if err != nil {
 			return fmt.Errorf("failed to set Team field: %w", err)
 		}
@@ -148,24 +122,12 @@ func runFieldSet(cmd *cobra.Command, args []string) error {
 
 	// Set Priority field if specified
 	if priorityValue != "" {
-		priorityField := gh.FindFieldByName(fields, "Priority")
-		if priorityField == nil {
-			return fmt.Errorf("Priority field not found in project")
-		}
-
-		// Find the option ID for the priority value
-		var optionID string
-		for _, opt := range priorityField.Options {
-			if opt.Name == priorityValue {
-				optionID = opt.ID
-				break
-			}
-		}
-		if optionID == "" {
-			return fmt.Errorf("priority value '%s' not found in Priority field options", priorityValue)
+		priorityField, optionID, err := gh.FindFieldOption(ctx, client, projectNodeID, "Priority", priorityValue)
+		if err != nil {
+			return err
 		}
 
-		err = gh.UpdateProjectItemField(ctx, projectNodeID, projectItemID, priorityField.ID, optionID)
+		err = client.UpdateProjectItemField(ctx, projectNodeID, projectItemID, priorityField.ID, optionID)
 		if err != nil {
 			return fmt.Errorf("failed to set Priority field: %w", err)
 		}
@@ -174,24 +136,12 @@ func runFieldSet(cmd *cobra.Command, args []string) error {
 
 	// Set Type field if specified
 	if typeValue != "" {
-		typeField := gh.FindFieldByName(fields, "Type")
-		if typeField == nil {
-			return fmt.Errorf("Type field not found in project")
-		}
-
-		// Find the option ID for the type value
-		var optionID string
-		for _, opt := range typeField.Options {
-			if opt.Name == typeValue {
-				optionID = opt.ID
-				break
-			}
-		}
-		if optionID == "" {
-			return fmt.Errorf("type value '%s' not found in Type field options", typeValue)
+		typeField, optionID, err := gh.FindFieldOption(ctx, client, projectNodeID, "Type", typeValue)
+		if err != nil {
+			return err
 		}
 
-		err = gh.UpdateProjectItemField(ctx, projectNodeID, projectItemID, typeField.ID, optionID)
+		err = client.UpdateProjectItemField(ctx, projectNodeID, projectItemID, typeField.ID, optionID)
 		if err != nil {
 			return fmt.Errorf("failed to set Type field: %w", err)
 		}
@@ -202,7 +152,7 @@ func runFieldSet(cmd *cobra.Command, args []string) error {
 
 	// Auto-transfer if team was set and not disabled
 	if teamValue != "" && !noTransfer {
-		targetRepo, exists := teamRepoMapping[teamValue]
+		targetRepo, exists := cfg.TeamRepos[teamValue]
 		if !exists {
 			fmt.Printf("\n⚠️  Warning: No repository mapping found for team '%s', skipping transfer\n", teamValue)
 			return nil
@@ -217,7 +167,7 @@ func runFieldSet(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\n🚀 Auto-transferring to %s/%s based on Team field...\n", cfg.Owner, targetRepo)
 
 		sourceRepo := fmt.Sprintf("%s/%s", cfg.Owner, cfg.DefaultRepo)
-		_, err = gh.TransferIssue(ctx, issueNumber, cfg.Owner, targetRepo, sourceRepo)
+		_, err = client.TransferIssue(ctx, issueNumber, cfg.Owner, targetRepo, sourceRepo)
 		if err != nil {
 			return fmt.Errorf("failed to auto-transfer issue: %w", err)
 		}
@@ -233,7 +183,7 @@ func runFieldSet(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
-	fieldSetCmd.Flags().StringVar(&teamValue, "team", "", "Team value (Backend, App, Web, Auth) - automatically transfers to team repo")
+	fieldSetCmd.Flags().StringVar(&teamValue, "team", "", "Team value (a team of the context's team_repos) - automatically transfers to team repo")
 	fieldSetCmd.Flags().StringVar(&priorityValue, "priority", "", "Priority value (Critical, High, Medium, Low)")
 	fieldSetCmd.Flags().StringVar(&typeValue, "type", "", "Type value (Epic, User Story, Story, Task, Bug, Feature)")
 	fieldSetCmd.Flags().BoolVar(&noTransfer, "no-transfer", false, "Prevent automatic transfer when Team field is set")
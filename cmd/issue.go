@@ -16,5 +16,10 @@ available fields for any issue type.`,
 
 func init() {
 	issueCmd.AddCommand(issueCreateCmd)
+	issueCmd.AddCommand(issueListCmd)
+	issueCmd.AddCommand(issueViewCmd)
+	issueCmd.AddCommand(issueEditCmd)
+	issueCmd.AddCommand(issueImportCmd)
+	issueCmd.AddCommand(issuePromoteCmd)
 	rootCmd.AddCommand(issueCmd)
 }
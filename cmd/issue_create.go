@@ -3,13 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/editor"
 	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/gh/dryrun"
 	"github.com/Zytera/gh-project-management/internal/templates"
 	"github.com/Zytera/gh-project-management/pkg/issue"
+	"github.com/Zytera/gh-project-management/pkg/project"
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +23,7 @@ var (
 	issueTitle  string
 	issueFields []string // Format: "fieldname=value"
 	showFields  bool     // Flag to show available fields for a type
+	useEditor   bool     // Fill the template fields in $EDITOR
 
 	// Custom fields
 	createTeam       string
@@ -28,6 +33,16 @@ var (
 	// Dependencies and linking
 	createDependsOn []string // Issues that block this issue
 	createParent    string   // Parent issue to link to
+
+	// Labels, assignees and milestone
+	createLabels    []string // Added to the template's labels
+	createAssignees []string // Logins or @me
+	createMilestone string   // Milestone title or number
+
+	createDryRun bool // Print the mutations instead of sending them
+	createAtomic bool // Undo the completed steps if one fails
+
+	createDraft bool // Add a draft issue to the project instead
 )
 
 var issueCreateCmd = &cobra.Command{
@@ -40,6 +55,10 @@ to provide field values. You can see available fields for a type using --show-fi
 
 You can also set custom fields, dependencies, and parent links in a single command.
 
+If the template's title has placeholders, like title: "[{{team}}] {{summary}}",
+the title is rendered from the template fields ({{<field id>}}), the issue type
+({{type}}) and the team ({{team}}), and --title is only needed to override it.
+
 Examples:
   # Show available fields for a type
   gh project-management issue create --type epic --show-fields
@@ -73,13 +92,52 @@ Examples:
     --priority Critical \
     --no-transfer
 
+  # Add labels, assignees and a milestone (template labels are always applied)
+  gh project-management issue create --type bug \
+    --title "Login fails on Safari" \
+    --field description="..." \
+    --label frontend --assignee @me --assignee octocat \
+    --milestone "Sprint 12"
+
+  # Check a create without changing anything: resolves every ID, validates
+  # fields and options and prints the mutations in order
+  gh project-management issue create --type task \
+    --title "Implement API" \
+    --field description="Create REST endpoint" \
+    --team Backend --priority High --parent 44 --depends-on 45 \
+    --dry-run
+
+  # Delete the issue again if linking, setting fields, adding dependencies
+  # or the transfer fails, instead of leaving it half-configured
+  gh project-management issue create --type task \
+    --title "Implement API" \
+    --field description="Create REST endpoint" \
+    --team Backend --priority High --parent 44 \
+    --atomic
+
+  # Add a draft issue to the project only; promote it to an issue later
+  gh project-management issue create --type task \
+    --title "Implement API" \
+    --field description="Create REST endpoint" \
+    --team Backend --priority High \
+    --draft
+
+  # Write the fields in $EDITOR, as markdown sections of the template
+  gh project-management issue create --type task --editor
+
+  # Title rendered from a template with title: "[{{team}}] {{summary}}"
+  gh project-management issue create --type task \
+    --field summary="Rotate API keys" \
+    --field description="..." \
+    --team Backend
+
 Available default types: epic, user_story, task, bug, feature
 Custom types will be loaded from .github/ISSUE_TEMPLATE/ in your repository.`,
 	RunE: runIssueCreate,
 }
 
 func runIssueCreate(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -87,6 +145,17 @@ func runIssueCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if createDraft {
+		if err := checkDraftFlags(cmd); err != nil {
+			return err
+		}
+	}
+
 	// Prompt for issue type if not provided
 	if issueType == "" {
 		issueType, err = promptForIssueType()
@@ -99,7 +168,7 @@ func runIssueCreate(cmd *cobra.Command, args []string) error {
 	var template *templates.IssueTemplate
 	var templateSource string
 
-	template, templateSource, err = issue.GetTemplate(ctx, cfg.Owner, cfg.DefaultRepo, issueType)
+	template, templateSource, err = issue.GetTemplate(ctx, client, cfg.Owner, cfg.DefaultRepo, issueType)
 	if err != nil {
 		return fmt.Errorf("failed to get template for type '%s': %w\n\nAvailable default types: epic, user_story, task, bug, feature", issueType, err)
 	}
@@ -109,38 +178,33 @@ func runIssueCreate(cmd *cobra.Command, args []string) error {
 		return displayTemplateFields(template, templateSource)
 	}
 
-	// Prompt for title if not provided
-	if issueTitle == "" {
-		issueTitle, err = promptForTitle()
-		if err != nil {
-			return fmt.Errorf("failed to get issue title: %w", err)
-		}
-	}
-
 	// Parse field values
-	fields := make(map[string]string)
-	for _, fieldStr := range issueFields {
-		parts := strings.SplitN(fieldStr, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid field format '%s', expected 'fieldname=value'", fieldStr)
-		}
-		fieldName := strings.TrimSpace(parts[0])
-		fieldValue := strings.TrimSpace(parts[1])
-		fields[fieldName] = fieldValue
+	fields, err := parseFieldFlags(issueFields)
+	if err != nil {
+		return err
 	}
 
-	// Prompt for template fields interactively if none provided
-	if len(issueFields) == 0 {
+	// Fill the template fields in the editor, or prompt for them
+	// interactively if none provided
+	if useEditor {
+		fields, err = editTemplateFields(template, fields)
+		if err != nil {
+			return err
+		}
+	} else if len(issueFields) == 0 {
 		fields = promptForTemplateFields(template, fields)
 	}
 
+	// Dropdown fields left empty get their default option
+	fields = template.WithDefaults(fields)
+
 	// Check for missing required fields
 	missingFields := []string{}
 	for _, field := range template.Body {
 		if field.Type == templates.FieldTypeMarkdown {
 			continue
 		}
-		if field.Validations.Required {
+		if field.IsRequired() {
 			if _, exists := fields[field.ID]; !exists {
 				label := field.Attributes.Label
 				if label == "" {
@@ -170,6 +234,21 @@ func runIssueCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Render the template's title pattern, or prompt for the title if the
+	// pattern lacks values or the template has none
+	if issueTitle == "" {
+		rendered, missing := template.RenderTitle(issue.TitleValues(issueType, createTeam, fields))
+		if template.HasTitlePattern() && len(missing) == 0 {
+			issueTitle = strings.TrimSpace(rendered)
+			fmt.Printf("📌 Title: %s\n", issueTitle)
+		} else {
+			issueTitle, err = promptForTitle(rendered)
+			if err != nil {
+				return fmt.Errorf("failed to get issue title: %w", err)
+			}
+		}
+	}
+
 	if createPriority == "" {
 		priority, err := promptForPriority()
 		if err != nil {
@@ -179,9 +258,14 @@ func runIssueCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Drafts have no repository, so they can't be linked yet
+	if createDraft {
+		return runIssueCreateDraft(ctx, client, cfg, templateSource, fields)
+	}
+
 	// Prompt for parent if not provided
 	if createParent == "" {
-		parent, err := promptForParent(ctx, cfg)
+		parent, err := promptForParent(ctx, client, cfg)
 		if err != nil {
 			fmt.Printf("⚠️  Warning: Failed to prompt for parent: %v\n", err)
 		} else if parent != "" {
@@ -191,7 +275,7 @@ func runIssueCreate(cmd *cobra.Command, args []string) error {
 
 	// Prompt for dependencies if not provided
 	if len(createDependsOn) == 0 {
-		deps, err := promptForDependencies(ctx, cfg)
+		deps, err := promptForDependencies(ctx, client, cfg)
 		if err != nil {
 			fmt.Printf("⚠️  Warning: Failed to prompt for dependencies: %v\n", err)
 		} else if len(deps) > 0 {
@@ -199,171 +283,321 @@ func runIssueCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("Creating %s issue using %s...\n", issueType, templateSource)
+	if createDryRun {
+		return runIssueCreateDryRun(ctx, client, cfg, templateSource, fields)
+	}
+	return runIssueCreateService(ctx, client, cfg, templateSource, fields)
+}
 
-	// Create the issue
-	params := issue.CreateDynamicIssueParams{
-		Config:    cfg,
-		IssueType: issueType,
-		Title:     issueTitle,
-		Fields:    fields,
+// runIssueCreateService creates the issue through the project service, which
+// links it to its parent, sets its project fields, adds its dependencies and
+// transfers it to the team repository, printing each step as it completes.
+// With --atomic, failures are reported at the end with what was rolled back.
+func runIssueCreateService(ctx context.Context, client project.Client, cfg *project.Config, templateSource string, fields map[string]string) error {
+	service := project.New(client, cfg, func(event project.Event) {
+		switch {
+		case event.Err != nil && (createAtomic || event.Issue.IsZero()):
+			// Reported below
+		case event.Step == project.StepRollback:
+			// Reported below
+		case event.Err != nil:
+			fmt.Printf("⚠️  Warning: %v\n", event.Err)
+			if hint := gh.Hint(event.Err); hint != "" {
+				fmt.Printf("💡 %s\n", hint)
+			}
+		case event.Step == project.StepCreate:
+			fmt.Printf("✓ %s\n", event.Message)
+		default:
+			fmt.Printf("  ✓ %s\n", event.Message)
+		}
+	})
+
+	params, err := createIssueParams(service, fields)
+	if err != nil {
+		return err
 	}
 
-	result, err := issue.CreateDynamicIssue(ctx, params)
+	if createAtomic {
+		fmt.Printf("Creating %s issue using %s (atomic)...\n\n", issueType, templateSource)
+	} else {
+		fmt.Printf("Creating %s issue using %s...\n\n", issueType, templateSource)
+	}
+
+	result, err := service.CreateIssue(ctx, params)
 	if err != nil {
+		switch {
+		case result != nil && createAtomic:
+			printRollback(err, result.RolledBack)
+		case result != nil:
+			// The issue exists but is not in the project
+			fmt.Printf("\n⚠️  Issue %s was created but not added to the project: %s\n", result.Ref, result.Issue.URL)
+			fmt.Printf("  Add it with: gh project item-add %s --owner %s --url %s\n", cfg.ProjectID, cfg.Owner, result.Issue.URL)
+		}
 		return fmt.Errorf("failed to create issue: %w", err)
 	}
 
-	createdIssue := result.Issue
-	projectItemID := result.ProjectItemID
-
-	fmt.Printf("\n✓ Successfully created issue #%d: %s\n", createdIssue.Number, createdIssue.Title)
-	fmt.Printf("  URL: %s\n", createdIssue.URL)
+	fmt.Printf("\n✓ Successfully created issue %s: %s\n", result.Ref, result.Issue.Title)
+	if !result.Transferred {
+		fmt.Printf("  URL: %s\n", result.Issue.URL)
+	}
+	if len(result.Labels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(result.Labels, ", "))
+	}
+	if createAtomic {
+		// Only warnings that didn't stop the creation, like a failed label re-apply
+		for _, warning := range result.Warnings {
+			fmt.Printf("⚠️  Warning: %v\n", warning)
+			if hint := gh.Hint(warning); hint != "" {
+				fmt.Printf("💡 %s\n", hint)
+			}
+		}
+	}
 
-	// Link to parent if specified
-	if createParent != "" {
-		_, _, parentNumber, err := gh.ParseIssueReference(createParent, cfg.Owner, cfg.DefaultRepo)
-		if err != nil {
-			fmt.Printf("\n⚠️  Warning: Invalid parent reference '%s': %v\n", createParent, err)
-		} else {
-			fmt.Printf("\nLinking to parent issue #%d...\n", parentNumber)
-			err = gh.AddSubIssue(ctx, cfg.Owner, cfg.DefaultRepo, parentNumber, createdIssue.Number)
-			if err != nil {
-				fmt.Printf("⚠️  Warning: Failed to link to parent: %v\n", err)
-			} else {
-				fmt.Printf("✓ Linked to parent issue #%d\n", parentNumber)
+	if params.Transfer && !result.Transferred {
+		if _, ok := cfg.TeamRepos[createTeam]; !ok {
+			teams := make([]string, 0, len(cfg.TeamRepos))
+			for team := range cfg.TeamRepos {
+				teams = append(teams, team)
 			}
+			sort.Strings(teams)
+			fmt.Printf("💡 Teams with a repository in this context: %s\n", strings.Join(teams, ", "))
 		}
 	}
+	if result.Transferred && createMilestone != "" {
+		fmt.Printf("⚠️  Warning: Milestones are not kept by transfers; set one in %s/%s if needed\n", result.Ref.Owner, result.Ref.Repo)
+	}
 
-	// Set custom fields if any are specified
-	if createTeam != "" || createPriority != "" {
-		fmt.Printf("\nSetting custom fields...\n")
+	fmt.Printf("\nNext steps:\n")
+	if result.Transferred {
+		fmt.Printf("  1. Update parent issue body with cross-repo reference: ### %s\n", result.Ref)
+		return nil
+	}
+	if createParent == "" {
+		fmt.Printf("  1. Link to parent: gh project-management link add <parent> %d\n", result.Ref.Number)
+	}
+	if createTeam == "" && createPriority == "" {
+		fmt.Printf("  2. Set custom fields: gh project-management field set %d --team <team> --priority <priority>\n", result.Ref.Number)
+	}
+	if len(createDependsOn) == 0 {
+		fmt.Printf("  3. Add dependencies: gh project-management dependency add %d <blocking-issue>\n", result.Ref.Number)
+	}
+	if createTeam != "" && createNoTransfer {
+		fmt.Printf("  4. Transfer manually: gh project-management transfer issue %d --target <repo>\n", result.Ref.Number)
+	} else if createTeam == "" {
+		fmt.Printf("  4. Set team and transfer: gh project-management field set %d --team <team> --transfer\n", result.Ref.Number)
+	}
+	return nil
+}
 
-		// Get project number
-		projectNumber, err := strconv.Atoi(cfg.ProjectID)
-		if err != nil {
-			fmt.Printf("⚠️  Warning: Invalid project ID: %v\n", err)
+// printRollback reports the step that failed an atomic creation and the
+// completed steps that were undone because of it
+func printRollback(err error, rolledBack []project.RolledBackStep) {
+	fmt.Printf("\n✗ %v\n", err)
+	if hint := gh.Hint(err); hint != "" {
+		fmt.Printf("💡 %s\n", hint)
+	}
+	fmt.Printf("\n↩️  Rolled back:\n")
+	failed := 0
+	for _, step := range rolledBack {
+		if step.Err != nil {
+			fmt.Printf("  ✗ %s: %v\n", step.Description, step.Err)
+			failed++
 		} else {
-			// Get project node ID
-			var projectNodeID string
-			if cfg.OwnerType == config.OwnerTypeOrg {
-				projectNodeID, err = gh.GetProjectNodeID(ctx, cfg.Owner, projectNumber)
-			} else {
-				projectNodeID, err = gh.GetUserProjectNodeID(ctx, projectNumber)
-			}
-			if err != nil {
-				fmt.Printf("⚠️  Warning: Failed to get project: %v\n", err)
-			} else {
-				// Get project fields
-				fields, err := gh.GetProjectFields(ctx, projectNodeID)
-				if err != nil {
-					fmt.Printf("⚠️  Warning: Failed to get project fields: %v\n", err)
-				} else {
-					// Set Team field
-					if createTeam != "" {
-						if err := setFieldValue(ctx, projectNodeID, projectItemID, fields, "Team", createTeam); err != nil {
-							fmt.Printf("⚠️  Warning: Failed to set Team: %v\n", err)
-						} else {
-							fmt.Printf("  ✓ Team: %s\n", createTeam)
-						}
-					}
+			fmt.Printf("  ✓ %s\n", step.Description)
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("\n⚠️  %d of %d steps could not be undone; clean them up by hand\n", failed, len(rolledBack))
+	}
+}
 
-					// Set Priority field
-					if createPriority != "" {
-						if err := setFieldValue(ctx, projectNodeID, projectItemID, fields, "Priority", createPriority); err != nil {
-							fmt.Printf("⚠️  Warning: Failed to set Priority: %v\n", err)
-						} else {
-							fmt.Printf("  ✓ Priority: %s\n", createPriority)
-						}
-					}
-				}
-			}
+// checkDraftFlags rejects the flags that need the issue to be in a repository
+func checkDraftFlags(cmd *cobra.Command) error {
+	for _, name := range []string{"parent", "depends-on", "label", "milestone", "no-transfer", "dry-run", "atomic"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s can't be used with --draft: a draft issue has no repository until it is promoted", name)
 		}
 	}
+	return nil
+}
+
+// runIssueCreateDraft adds the issue to the project as a draft issue
+func runIssueCreateDraft(ctx context.Context, client gh.Client, cfg *config.Config, templateSource string, fields map[string]string) error {
+	fmt.Printf("Adding %s draft issue to the project using %s...\n", issueType, templateSource)
+
+	projectFields := make(map[string]string)
+	if createTeam != "" {
+		projectFields["Team"] = createTeam
+	}
+	if createPriority != "" {
+		projectFields["Priority"] = createPriority
+	}
 
-	// Add dependencies if specified
-	if len(createDependsOn) > 0 {
-		fmt.Printf("\nAdding dependencies...\n")
-		for _, depRef := range createDependsOn {
-			_, _, depNumber, err := gh.ParseIssueReference(depRef, cfg.Owner, cfg.DefaultRepo)
-			if err != nil {
-				fmt.Printf("⚠️  Warning: Invalid dependency reference '%s': %v\n", depRef, err)
-				continue
-			}
+	result, err := issue.CreateDraftIssue(ctx, client, issue.CreateDraftIssueParams{
+		Config:        cfg,
+		IssueType:     issueType,
+		Title:         issueTitle,
+		Team:          createTeam,
+		Fields:        fields,
+		Assignees:     createAssignees,
+		ProjectFields: projectFields,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create draft issue: %w", err)
+	}
 
-			// This issue is blocked by depNumber
-			err = gh.AddBlockedBy(ctx, cfg.Owner, cfg.DefaultRepo, createdIssue.Number, depNumber)
-			if err != nil {
-				fmt.Printf("⚠️  Warning: Failed to add dependency on #%d: %v\n", depNumber, err)
-			} else {
-				fmt.Printf("  ✓ Blocked by issue #%d\n", depNumber)
-			}
+	fmt.Printf("\n✓ Successfully added draft issue: %s\n", result.Title)
+	fmt.Printf("  Item: %s\n", result.ProjectItemID)
+	for _, name := range []string{"Team", "Priority"} {
+		if value, ok := result.Fields[name]; ok {
+			fmt.Printf("  ✓ %s: %s\n", name, value)
+		}
+	}
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠️  Warning: %v\n", warning)
+		if hint := gh.Hint(warning); hint != "" {
+			fmt.Printf("💡 %s\n", hint)
 		}
 	}
 
-	// Auto-transfer if Team is set and not disabled
-	if createTeam != "" && !createNoTransfer {
-		targetRepo, exists := teamRepoMapping[createTeam]
-		if !exists {
-			fmt.Printf("\n⚠️  Warning: No repository mapping found for team '%s', skipping transfer\n", createTeam)
-			fmt.Printf("💡 Available teams: Backend, App, Web, Auth\n")
-		} else {
-			fmt.Printf("\n🚀 Auto-transferring to %s/%s based on Team field...\n", cfg.Owner, targetRepo)
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  1. Groom the draft in the project\n")
+	fmt.Printf("  2. Turn it into an issue: gh project-management issue promote %s\n", result.ProjectItemID)
+	return nil
+}
 
-			sourceRepo := fmt.Sprintf("%s/%s", cfg.Owner, cfg.DefaultRepo)
-			_, err = gh.TransferIssue(ctx, createdIssue.Number, cfg.Owner, targetRepo, sourceRepo)
-			if err != nil {
-				fmt.Printf("⚠️  Warning: Failed to transfer: %v\n", err)
-			} else {
-				fmt.Printf("\n✓ Successfully transferred to %s/%s\n", cfg.Owner, targetRepo)
-				fmt.Printf("\nNext steps:\n")
-				fmt.Printf("  1. Note the new issue number from the output above\n")
-				fmt.Printf("  2. Update parent issue body with cross-repo reference: ### %s/%s#<new-number>\n", cfg.Owner, targetRepo)
-			}
+// createIssueParams builds the service parameters of issue create from its flags
+func createIssueParams(service *project.Service, fields map[string]string) (project.CreateIssueParams, error) {
+	params := project.CreateIssueParams{
+		Type:          issueType,
+		Title:         issueTitle,
+		Fields:        fields,
+		ProjectFields: make(map[string]string),
+		Transfer:      createTeam != "" && !createNoTransfer,
+		Labels:        createLabels,
+		Assignees:     createAssignees,
+		Milestone:     createMilestone,
+		Atomic:        createAtomic,
+	}
+	if createTeam != "" {
+		params.ProjectFields["Team"] = createTeam
+	}
+	if createPriority != "" {
+		params.ProjectFields["Priority"] = createPriority
+	}
+	if createParent != "" {
+		parent, err := service.ParseRef(createParent)
+		if err != nil {
+			return params, fmt.Errorf("invalid parent reference '%s': %w", createParent, err)
 		}
-	} else {
-		// Show next steps if not auto-transferred
-		fmt.Printf("\nNext steps:\n")
-		if createParent == "" {
-			fmt.Printf("  1. Link to parent: gh project-management link add <parent> %d\n", createdIssue.Number)
+		params.Parent = parent
+	}
+	for _, depRef := range createDependsOn {
+		blocking, err := service.ParseRef(depRef)
+		if err != nil {
+			return params, fmt.Errorf("invalid dependency reference '%s': %w", depRef, err)
 		}
-		if createTeam == "" && createPriority == "" {
-			fmt.Printf("  2. Set custom fields: gh project-management field set %d --team <team> --priority <priority>\n", createdIssue.Number)
+		params.BlockedBy = append(params.BlockedBy, blocking)
+	}
+	return params, nil
+}
+
+// runIssueCreateDryRun runs every step of issue create against a client that
+// only records mutations, then prints them in order. IDs are resolved and
+// field options checked against GitHub, but nothing is changed.
+func runIssueCreateDryRun(ctx context.Context, client gh.Client, cfg *config.Config, templateSource string, fields map[string]string) error {
+	recorder := dryrun.New(client)
+
+	// A real run only warns when a step after the creation fails; a dry run reports it as a failure
+	var warnings, failures []error
+	service := project.New(recorder, cfg, func(event project.Event) {
+		switch {
+		case event.Err == nil:
+		case event.Step == project.StepCreate:
+			warnings = append(warnings, event.Err)
+		default:
+			failures = append(failures, event.Err)
 		}
-		if len(createDependsOn) == 0 {
-			fmt.Printf("  3. Add dependencies: gh project-management dependency add %d <blocking-issue>\n", createdIssue.Number)
+	})
+
+	params, err := createIssueParams(service, fields)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Dry run: resolving %s issue using %s...\n", issueType, templateSource)
+
+	if _, err := service.CreateIssue(ctx, params); err != nil {
+		return fmt.Errorf("dry run failed: %w", err)
+	}
+
+	mutations := recorder.Mutations()
+	fmt.Printf("\nMutations that would run, in order:\n")
+	for i, mutation := range mutations {
+		fmt.Printf("\n  %d. %s\n", i+1, mutation.Name)
+		printMutationVariables(mutation.Variables)
+	}
+
+	if len(warnings)+len(failures) > 0 {
+		fmt.Println()
+	}
+	for _, warning := range warnings {
+		fmt.Printf("⚠️  Warning: %v\n", warning)
+		if hint := gh.Hint(warning); hint != "" {
+			fmt.Printf("💡 %s\n", hint)
 		}
-		if createTeam != "" && createNoTransfer {
-			fmt.Printf("  4. Transfer manually: gh project-management transfer issue %d --target <repo>\n", createdIssue.Number)
-		} else if createTeam == "" {
-			fmt.Printf("  4. Set team and transfer: gh project-management field set %d --team <team> --transfer\n", createdIssue.Number)
+	}
+	for _, failure := range failures {
+		fmt.Printf("✗ Would fail: %v\n", failure)
+		if hint := gh.Hint(failure); hint != "" {
+			fmt.Printf("💡 %s\n", hint)
 		}
 	}
 
+	if len(failures) > 0 {
+		return fmt.Errorf("dry run: %d steps would fail, nothing was sent", len(failures))
+	}
+	fmt.Printf("\n✓ Dry run complete: %d mutations, nothing was sent\n", len(mutations))
 	return nil
 }
 
-// setFieldValue sets a single-select field value in the project
-func setFieldValue(ctx context.Context, projectNodeID, projectItemID string, fields []gh.Field, fieldName, value string) error {
-	field := gh.FindFieldByName(fields, fieldName)
-	if field == nil {
-		return fmt.Errorf("%s field not found in project", fieldName)
+// printMutationVariables prints single-line variables sorted by name, then
+// multi-line ones like the issue body as indented blocks
+func printMutationVariables(variables map[string]string) {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	// Find the option ID for the value
-	var optionID string
-	for _, opt := range field.Options {
-		if opt.Name == value {
-			optionID = opt.ID
-			break
+	for _, name := range names {
+		if value := variables[name]; !strings.Contains(value, "\n") {
+			fmt.Printf("       %s: %s\n", name, value)
 		}
 	}
-	if optionID == "" {
-		return fmt.Errorf("value '%s' not found in %s field options", value, fieldName)
+	for _, name := range names {
+		if value := variables[name]; strings.Contains(value, "\n") {
+			fmt.Printf("       %s:\n", name)
+			for _, line := range strings.Split(strings.TrimRight(value, "\n"), "\n") {
+				fmt.Printf("         | %s\n", line)
+			}
+		}
 	}
+}
 
-	return gh.UpdateProjectItemField(ctx, projectNodeID, projectItemID, field.ID, optionID)
+// parseFieldFlags parses repeated --field flags in the format "fieldname=value"
+func parseFieldFlags(values []string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for _, fieldStr := range values {
+		parts := strings.SplitN(fieldStr, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid field format '%s', expected 'fieldname=value'", fieldStr)
+		}
+		fieldName := strings.TrimSpace(parts[0])
+		fieldValue := strings.TrimSpace(parts[1])
+		fields[fieldName] = fieldValue
+	}
+	return fields, nil
 }
 
 func displayTemplateFields(template *templates.IssueTemplate, source string) error {
@@ -373,6 +607,9 @@ func displayTemplateFields(template *templates.IssueTemplate, source string) err
 	if template.Description != "" {
 		fmt.Printf("Description: %s\n", template.Description)
 	}
+	if template.HasTitlePattern() {
+		fmt.Printf("Title pattern: %s\n", template.Title)
+	}
 	fmt.Printf("\n")
 
 	// Display required fields
@@ -383,7 +620,7 @@ func displayTemplateFields(template *templates.IssueTemplate, source string) err
 		if field.Type == templates.FieldTypeMarkdown {
 			continue
 		}
-		if field.Validations.Required {
+		if field.IsRequired() {
 			requiredFields = append(requiredFields, field)
 		} else {
 			optionalFields = append(optionalFields, field)
@@ -405,6 +642,12 @@ func displayTemplateFields(template *templates.IssueTemplate, source string) err
 			if description != "" {
 				fmt.Printf("\n      %s", description)
 			}
+			if usage := optionsUsage(field); usage != "" {
+				fmt.Printf("\n      %s", usage)
+			}
+			for _, rule := range field.RuleDescriptions() {
+				fmt.Printf("\n      %s", rule)
+			}
 			fmt.Println()
 		}
 		fmt.Println()
@@ -425,6 +668,12 @@ func displayTemplateFields(template *templates.IssueTemplate, source string) err
 			if description != "" {
 				fmt.Printf("\n      %s", description)
 			}
+			if usage := optionsUsage(field); usage != "" {
+				fmt.Printf("\n      %s", usage)
+			}
+			for _, rule := range field.RuleDescriptions() {
+				fmt.Printf("\n      %s", rule)
+			}
 			fmt.Println()
 		}
 		fmt.Println()
@@ -441,7 +690,22 @@ func displayTemplateFields(template *templates.IssueTemplate, source string) err
 			if label == "" {
 				label = field.ID
 			}
-			fmt.Printf("    --field %s=\"%s value\"", field.ID, label)
+			example := label + " value"
+			if field.Type == templates.FieldTypeCheckboxes {
+				var checked []string
+				for _, option := range field.CheckboxOptions() {
+					if option.Required {
+						checked = append(checked, option.Label)
+					}
+				}
+				if len(checked) == 0 && len(field.Attributes.Options) > 0 {
+					checked = field.Attributes.Options[:1]
+				}
+				example = strings.Join(checked, ",")
+			} else if field.Type == templates.FieldTypeDropdown && len(field.Attributes.Options) > 0 {
+				example = field.Attributes.Options[0]
+			}
+			fmt.Printf("    --field %s=\"%s\"", field.ID, example)
 			if i < len(requiredFields)-1 {
 				fmt.Printf(" \\")
 			}
@@ -454,8 +718,39 @@ func displayTemplateFields(template *templates.IssueTemplate, source string) err
 	return nil
 }
 
+// optionsUsage lists the options of a dropdown or checkboxes field for
+// --show-fields, or returns "" for other fields
+func optionsUsage(field templates.BodyField) string {
+	switch field.Type {
+	case templates.FieldTypeDropdown:
+		if len(field.Attributes.Options) == 0 {
+			return ""
+		}
+		usage := "Options: "
+		if field.Attributes.Multiple {
+			usage = "Options, one or more comma-separated: "
+		}
+		usage += strings.Join(field.Attributes.Options, ", ")
+		if option := field.DefaultOption(); option != "" {
+			usage += fmt.Sprintf(" (default: %s)", option)
+		}
+		return usage
+	case templates.FieldTypeCheckboxes:
+		options := make([]string, 0, len(field.Attributes.Options))
+		for _, option := range field.CheckboxOptions() {
+			if option.Required {
+				options = append(options, option.Label+" (required)")
+			} else {
+				options = append(options, option.Label)
+			}
+		}
+		return "Options to check, comma-separated: " + strings.Join(options, ", ")
+	}
+	return ""
+}
+
 // promptForParent asks the user if they want to link to a parent issue
-func promptForParent(ctx context.Context, cfg *config.Config) (string, error) {
+func promptForParent(ctx context.Context, client gh.Client, cfg *config.Config) (string, error) {
 	fmt.Println()
 
 	var wantsParent bool
@@ -477,7 +772,7 @@ func promptForParent(ctx context.Context, cfg *config.Config) (string, error) {
 	}
 
 	// List recent issues
-	issues, err := gh.ListRecentIssues(ctx, cfg.Owner, cfg.DefaultRepo, 20)
+	issues, err := client.ListRecentIssues(ctx, cfg.Owner, cfg.DefaultRepo, 20)
 	if err != nil {
 		return "", err
 	}
@@ -513,7 +808,7 @@ func promptForParent(ctx context.Context, cfg *config.Config) (string, error) {
 }
 
 // promptForDependencies asks the user if they want to add dependencies
-func promptForDependencies(ctx context.Context, cfg *config.Config) ([]string, error) {
+func promptForDependencies(ctx context.Context, client gh.Client, cfg *config.Config) ([]string, error) {
 	fmt.Println()
 
 	var wantsDependencies bool
@@ -535,7 +830,7 @@ func promptForDependencies(ctx context.Context, cfg *config.Config) ([]string, e
 	}
 
 	// List recent issues
-	issues, err := gh.ListRecentIssues(ctx, cfg.Owner, cfg.DefaultRepo, 20)
+	issues, err := client.ListRecentIssues(ctx, cfg.Owner, cfg.DefaultRepo, 20)
 	if err != nil {
 		return nil, err
 	}
@@ -638,11 +933,11 @@ func promptForIssueType() (string, error) {
 	return selectedType, nil
 }
 
-// promptForTitle asks the user for an issue title
-func promptForTitle() (string, error) {
+// promptForTitle asks the user for an issue title, starting from initial
+func promptForTitle(initial string) (string, error) {
 	fmt.Println()
 
-	var title string
+	title := initial
 	titleForm := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
@@ -776,8 +1071,8 @@ func promptForTemplateFields(template *templates.IssueTemplate, existingFields m
 			continue
 		}
 
-		// Only process input fields (textarea, input, dropdown)
-		if field.Type != "textarea" && field.Type != "input" && field.Type != "dropdown" {
+		// Only process input fields (textarea, input, dropdown, checkboxes)
+		if field.Type != "textarea" && field.Type != "input" && field.Type != "dropdown" && field.Type != "checkboxes" {
 			continue
 		}
 
@@ -809,23 +1104,77 @@ func promptForTemplateFields(template *templates.IssueTemplate, existingFields m
 				selectOptions[i] = huh.NewOption(option, option)
 			}
 
-			dropdownForm := huh.NewForm(
+			// The default option starts selected
+			value = field.DefaultOption()
+
+			var dropdownField huh.Field
+			var selected []string
+			if field.Attributes.Multiple {
+				if value != "" {
+					selected = []string{value}
+				}
+				dropdownField = huh.NewMultiSelect[string]().
+					Title(title).
+					Description(description).
+					Options(selectOptions...).
+					Value(&selected).
+					Validate(func(s []string) error {
+						if required && len(s) == 0 {
+							return fmt.Errorf("select at least one option")
+						}
+						return nil
+					})
+			} else {
+				dropdownField = huh.NewSelect[string]().
+					Title(title).
+					Description(description).
+					Options(selectOptions...).
+					Value(&value)
+			}
+
+			dropdownForm := huh.NewForm(huh.NewGroup(dropdownField))
+
+			if err := dropdownForm.Run(); err != nil {
+				// User cancelled or error
+				if required {
+					fmt.Printf("⚠️  Warning: Skipping required field '%s'\n", field.ID)
+				}
+				continue
+			}
+			if field.Attributes.Multiple {
+				value = strings.Join(selected, ", ")
+			}
+		} else if field.Type == "checkboxes" {
+			// Handle checkboxes fields as a multi-select
+			var checked []string
+			checkboxOptions := field.CheckboxOptions()
+			selectOptions := make([]huh.Option[string], len(checkboxOptions))
+			for i, option := range checkboxOptions {
+				label := option.Label
+				if option.Required {
+					label += " (required)"
+				}
+				selectOptions[i] = huh.NewOption(label, option.Label)
+			}
+
+			checkboxForm := huh.NewForm(
 				huh.NewGroup(
-					huh.NewSelect[string]().
+					huh.NewMultiSelect[string]().
 						Title(title).
 						Description(description).
 						Options(selectOptions...).
-						Value(&value),
+						Value(&checked).
+						Validate(func(s []string) error {
+							return field.ValidateFieldValue(strings.Join(s, ", "))
+						}),
 				),
 			)
 
-			if err := dropdownForm.Run(); err != nil {
-				// User cancelled or error
-				if required {
-					fmt.Printf("⚠️  Warning: Skipping required field '%s'\n", field.ID)
-				}
+			if err := checkboxForm.Run(); err != nil {
+				// User cancelled or error, continue to next field
 				continue
 			}
+			value = strings.Join(checked, ", ")
 		} else if field.Type == "textarea" {
 			// Use Text for multiline input
 			textForm := huh.NewForm(
@@ -838,7 +1187,7 @@ func promptForTemplateFields(template *templates.IssueTemplate, existingFields m
 							if required && strings.TrimSpace(s) == "" {
 								return fmt.Errorf("this field is required")
 							}
-							return nil
+							return field.ValidateFieldValue(strings.TrimSpace(s))
 						}),
 				),
 			)
@@ -859,7 +1208,7 @@ func promptForTemplateFields(template *templates.IssueTemplate, existingFields m
 							if required && strings.TrimSpace(s) == "" {
 								return fmt.Errorf("this field is required")
 							}
-							return nil
+							return field.ValidateFieldValue(strings.TrimSpace(s))
 						}),
 				),
 			)
@@ -880,6 +1229,72 @@ func promptForTemplateFields(template *templates.IssueTemplate, existingFields m
 	return fields
 }
 
+// editTemplateFields lets the user fill the template fields in $EDITOR,
+// starting from the --field values and the dropdown defaults. The editor is
+// reopened with the problems listed until the fields are valid or the user
+// gives up.
+func editTemplateFields(template *templates.IssueTemplate, fields map[string]string) (map[string]string, error) {
+	buffer := templates.RenderEditorBuffer(template, &templates.ParsedBody{Fields: template.WithDefaults(fields)}, nil)
+
+	for {
+		fmt.Printf("📝 Opening %s...\n", editor.Command())
+		edited, err := editor.Edit("issue-*.md", buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed := templates.ParseEditorBuffer(template, edited)
+		problems := editorProblems(template, parsed)
+		if len(problems) == 0 {
+			return parsed.Fields, nil
+		}
+
+		fmt.Printf("\n✗ The edited issue has %d problems:\n", len(problems))
+		for _, problem := range problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+
+		var reopen bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("Reopen the editor?").
+					Description("Your changes are kept").
+					Affirmative("Reopen").
+					Negative("Cancel").
+					Value(&reopen),
+			),
+		)
+		if err := confirmForm.Run(); err != nil || !reopen {
+			return nil, fmt.Errorf("the edited issue has %d problems", len(problems))
+		}
+
+		buffer = templates.RenderEditorBuffer(template, parsed, problems)
+	}
+}
+
+// editorProblems validates the fields read back from the editor. Text outside
+// the template's sections is a problem too, as the issue body would drop it.
+func editorProblems(template *templates.IssueTemplate, parsed *templates.ParsedBody) []string {
+	var problems []string
+	if parsed.Preamble != "" {
+		problems = append(problems, "text before the first section is not part of any field")
+	}
+	for _, section := range parsed.Unknown {
+		problems = append(problems, fmt.Sprintf("section '### %s' matches no template field (or repeats one)", section.Label))
+	}
+	for _, field := range template.GetAllInputFields() {
+		if slices.Contains(parsed.Missing, field.ID) && field.DefaultOption() == "" {
+			problems = append(problems, fmt.Sprintf("required field '%s' (%s) is empty", field.ID, field.Attributes.Label))
+			continue
+		}
+		if value, ok := parsed.Fields[field.ID]; ok {
+			problems = append(problems, templates.Violations(field.ValidateFieldValue(value))...)
+		}
+	}
+	return problems
+}
+
 // isFieldRequired checks if a field is required in the template
 func isFieldRequired(field templates.BodyField, template *templates.IssueTemplate) bool {
 	for _, reqField := range template.GetRequiredFields() {
@@ -893,16 +1308,29 @@ func isFieldRequired(field templates.BodyField, template *templates.IssueTemplat
 func init() {
 	// Template flags
 	issueCreateCmd.Flags().StringVar(&issueType, "type", "", "Issue type (epic, user_story, task, bug, feature, or custom)")
-	issueCreateCmd.Flags().StringVar(&issueTitle, "title", "", "Issue title (required)")
+	issueCreateCmd.Flags().StringVar(&issueTitle, "title", "", "Issue title (defaults to the template's title pattern)")
 	issueCreateCmd.Flags().StringArrayVar(&issueFields, "field", []string{}, "Field values in format 'fieldname=value' (can be repeated)")
 	issueCreateCmd.Flags().BoolVar(&showFields, "show-fields", false, "Show available fields for the specified type")
+	issueCreateCmd.Flags().BoolVar(&useEditor, "editor", false, "Fill the template fields in $EDITOR (--field values are prefilled)")
 
 	// Custom fields
-	issueCreateCmd.Flags().StringVar(&createTeam, "team", "", "Team value (Backend, App, Web, Auth) - automatically transfers to team repo")
+	issueCreateCmd.Flags().StringVar(&createTeam, "team", "", "Team value (a team of the context's team_repos) - automatically transfers to team repo")
 	issueCreateCmd.Flags().StringVar(&createPriority, "priority", "", "Priority value (Critical, High, Medium, Low)")
 	issueCreateCmd.Flags().BoolVar(&createNoTransfer, "no-transfer", false, "Prevent automatic transfer when Team field is set")
 
 	// Dependencies and linking
 	issueCreateCmd.Flags().StringArrayVar(&createDependsOn, "depends-on", []string{}, "Issues that block this issue (can be repeated)")
 	issueCreateCmd.Flags().StringVar(&createParent, "parent", "", "Parent issue to link to")
+
+	// Labels, assignees and milestone
+	issueCreateCmd.Flags().StringArrayVar(&createLabels, "label", []string{}, "Label to add besides the template's labels (can be repeated)")
+	issueCreateCmd.Flags().StringArrayVar(&createAssignees, "assignee", []string{}, "User to assign, or @me (can be repeated)")
+	issueCreateCmd.Flags().StringVar(&createMilestone, "milestone", "", "Milestone title or number")
+
+	// Dry run and rollback
+	issueCreateCmd.Flags().BoolVar(&createDryRun, "dry-run", false, "Validate and print the mutations that would run, without sending them")
+	issueCreateCmd.Flags().BoolVar(&createAtomic, "atomic", false, "Undo the completed steps and delete the issue if a step fails")
+
+	// Drafts
+	issueCreateCmd.Flags().BoolVar(&createDraft, "draft", false, "Add a draft issue to the project instead, with no repository (see issue promote)")
 }
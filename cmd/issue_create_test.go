@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/gh/fake"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// taskFields are the required fields of the default task template
+var taskFields = []string{
+	"--field", "description=Add a login endpoint",
+	"--field", "checklist=- [ ] Handler",
+	"--field", "acceptance_criteria=Returns a token",
+}
+
+// writeTestConfig writes a context for org acme, with project 1, default
+// repository pm and team Backend in repository backend, to a temporary home.
+// It returns the team repositories.
+func writeTestConfig(t *testing.T) map[string]string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	teamRepos := map[string]string{"Backend": "backend"}
+	err := config.Save(&config.GlobalConfig{
+		CurrentContext: "test",
+		Contexts: map[string]config.Context{
+			"test": {
+				OwnerType:   config.OwnerTypeOrg,
+				Owner:       "acme",
+				ProjectID:   "1",
+				ProjectName: "Roadmap",
+				DefaultRepo: "pm",
+				TeamRepos:   teamRepos,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	return teamRepos
+}
+
+// setupCommandTest writes the test context and returns a fake backend holding
+// its organization, repositories and project. It seeds acme/pm#1, acme/pm#2
+// and acme/backend#1.
+func setupCommandTest(t *testing.T) (*fake.Client, *fake.Project) {
+	t.Helper()
+	teamRepos := writeTestConfig(t)
+
+	client := fake.New("me")
+	client.AddOrganization("acme", "Acme")
+	client.AddRepo("acme", "pm")
+	client.AddRepo("acme", "backend")
+	p := client.AddProject("acme", 1, "Roadmap")
+
+	ctx := context.Background()
+	if _, err := gh.EnsureTeamField(ctx, client, p.ID, teamRepos); err != nil {
+		t.Fatalf("failed to create Team field: %v", err)
+	}
+	if _, err := gh.EnsurePriorityField(ctx, client, p.ID); err != nil {
+		t.Fatalf("failed to create Priority field: %v", err)
+	}
+	for _, seed := range []struct{ repo, title string }{
+		{"pm", "Login epic"},
+		{"pm", "Set up database"},
+		{"backend", "Existing backend issue"},
+	} {
+		if _, err := client.CreateIssue(ctx, "acme", seed.repo, seed.title, "", gh.CreateIssueOptions{}); err != nil {
+			t.Fatalf("failed to seed issue: %v", err)
+		}
+	}
+	return client, p
+}
+
+// executeCommand runs the root command with args against client, or against
+// the client the command builds itself if client is nil
+func executeCommand(t *testing.T, client gh.Client, args ...string) error {
+	t.Helper()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), config.ConfigKey{}, cfg)
+	if client != nil {
+		ctx = context.WithValue(ctx, gh.ClientKey{}, client)
+	}
+	resetCommand(rootCmd, ctx)
+	rootCmd.SetArgs(args)
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// captureStdout returns what run prints to stdout
+func captureStdout(t *testing.T, run func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+	run()
+	w.Close()
+	return <-output
+}
+
+// resetCommand undoes what a previous run left on cmd and its subcommands:
+// flags get their default values back and every command gets ctx, as cobra
+// only passes the context down to commands that have none
+func resetCommand(cmd *cobra.Command, ctx context.Context) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if values, ok := flag.Value.(pflag.SliceValue); ok {
+			values.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	})
+	cmd.SetContext(ctx)
+	for _, sub := range cmd.Commands() {
+		resetCommand(sub, ctx)
+	}
+}
+
+// issueNodeID returns the node ID of a seeded or created issue
+func issueNodeID(t *testing.T, client *fake.Client, repo string, number int) string {
+	t.Helper()
+	issue, ok := client.Issue("acme", repo, number)
+	if !ok {
+		t.Fatalf("issue acme/%s#%d not found", repo, number)
+	}
+	return issue.ID
+}
+
+// fieldOptionID returns the option ID selected for a field on the project item of an issue
+func fieldOptionID(t *testing.T, client *fake.Client, p *fake.Project, issueNodeID, fieldName string) string {
+	t.Helper()
+	fields, err := client.GetProjectFields(context.Background(), p.ID)
+	if err != nil {
+		t.Fatalf("failed to get project fields: %v", err)
+	}
+	field := gh.FindFieldByName(fields, fieldName)
+	if field == nil {
+		t.Fatalf("project has no %s field", fieldName)
+	}
+	for _, item := range p.Items {
+		if item.ContentID == issueNodeID {
+			return item.FieldValues[field.ID]
+		}
+	}
+	t.Fatalf("issue %s is not in the project", issueNodeID)
+	return ""
+}
+
+// optionID returns the ID of a project field option
+func optionID(t *testing.T, client *fake.Client, p *fake.Project, fieldName, option string) string {
+	t.Helper()
+	if option == "" {
+		return ""
+	}
+	_, id, err := gh.FindFieldOption(context.Background(), client, p.ID, fieldName, option)
+	if err != nil {
+		t.Fatalf("failed to find option %s of field %s: %v", option, fieldName, err)
+	}
+	return id
+}
+
+func TestIssueCreateLinksSetsFieldsAndTransfers(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		wantRepo      string // Where the created issue ends up
+		wantNumber    int
+		wantParent    int   // Number in acme/pm, 0 for none
+		wantBlockedBy []int // Numbers in acme/pm
+		wantTeam      string
+		wantPriority  string
+	}{
+		{
+			name:          "parent, dependency, team and priority",
+			args:          []string{"--parent", "1", "--depends-on", "2", "--team", "Backend", "--priority", "High"},
+			wantRepo:      "backend",
+			wantNumber:    2,
+			wantParent:    1,
+			wantBlockedBy: []int{2},
+			wantTeam:      "Backend",
+			wantPriority:  "High",
+		},
+		{
+			name:          "no transfer",
+			args:          []string{"--parent", "1", "--depends-on", "2", "--team", "Backend", "--priority", "Low", "--no-transfer"},
+			wantRepo:      "pm",
+			wantNumber:    3,
+			wantParent:    1,
+			wantBlockedBy: []int{2},
+			wantTeam:      "Backend",
+			wantPriority:  "Low",
+		},
+		{
+			name:          "full references without team",
+			args:          []string{"--parent", "acme/pm#1", "--depends-on", "acme/pm#2", "--depends-on", "#1", "--priority", "Critical"},
+			wantRepo:      "pm",
+			wantNumber:    3,
+			wantParent:    1,
+			wantBlockedBy: []int{2, 1},
+			wantPriority:  "Critical",
+		},
+		{
+			// acme/backend#1 must not be taken for acme/pm#1
+			name:         "dependency in another repository",
+			args:         []string{"--parent", "1", "--depends-on", "acme/backend#1", "--team", "Backend", "--priority", "Medium"},
+			wantRepo:     "backend",
+			wantNumber:   2,
+			wantParent:   1,
+			wantTeam:     "Backend",
+			wantPriority: "Medium",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, p := setupCommandTest(t)
+
+			args := append([]string{"issue", "create", "--type", "task", "--title", "Login endpoint"}, taskFields...)
+			if err := executeCommand(t, client, append(args, tt.args...)...); err != nil {
+				t.Fatalf("issue create failed: %v", err)
+			}
+
+			created, ok := client.Issue("acme", tt.wantRepo, tt.wantNumber)
+			if !ok || created.Title != "Login endpoint" {
+				t.Fatalf("created issue not found in acme/%s#%d", tt.wantRepo, tt.wantNumber)
+			}
+
+			wantParent := ""
+			if tt.wantParent != 0 {
+				wantParent = issueNodeID(t, client, "pm", tt.wantParent)
+			}
+			if created.Parent != wantParent {
+				t.Errorf("parent = %q, want %q", created.Parent, wantParent)
+			}
+
+			var wantBlockedBy []string
+			for _, number := range tt.wantBlockedBy {
+				wantBlockedBy = append(wantBlockedBy, issueNodeID(t, client, "pm", number))
+			}
+			if !slices.Equal(created.BlockedBy, wantBlockedBy) {
+				t.Errorf("blocked by %v, want %v", created.BlockedBy, wantBlockedBy)
+			}
+
+			if got, want := fieldOptionID(t, client, p, created.ID, "Team"), optionID(t, client, p, "Team", tt.wantTeam); got != want {
+				t.Errorf("Team option = %q, want %q (%s)", got, want, tt.wantTeam)
+			}
+			if got, want := fieldOptionID(t, client, p, created.ID, "Priority"), optionID(t, client, p, "Priority", tt.wantPriority); got != want {
+				t.Errorf("Priority option = %q, want %q (%s)", got, want, tt.wantPriority)
+			}
+		})
+	}
+}
+
+// TestIssueCreateReplaysFixture replays a run of
+// issue create --type task --team Backend --parent 44 --depends-on 45
+// through the client the command builds. The fixture is synthetic: it has the
+// recorder's format, but the acme organization and its node IDs are made up.
+// A request the fixture doesn't have fails its step, which the command reports
+// as a warning.
+func TestIssueCreateReplaysFixture(t *testing.T) {
+	writeTestConfig(t)
+	fixture, err := filepath.Abs(filepath.Join("testdata", "issue_create_team_parent.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(gh.ReplayEnvVar, fixture)
+
+	args := append([]string{"issue", "create", "--type", "task", "--title", "Login endpoint", "--team", "Backend", "--parent", "44", "--depends-on", "45"}, taskFields...)
+	var runErr error
+	output := captureStdout(t, func() {
+		runErr = executeCommand(t, nil, args...)
+	})
+	if runErr != nil {
+		t.Fatalf("issue create failed: %v\n%s", runErr, output)
+	}
+
+	for _, want := range []string{
+		"created task acme/pm#46: Login endpoint",
+		"linked to parent acme/pm#44",
+		"set Team to Backend",
+		"blocked by acme/pm#45",
+		"transferred acme/pm#46 to acme/backend#12",
+		"Successfully created issue acme/backend#12",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output lacks %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Warning: failed") {
+		t.Errorf("a step failed:\n%s", output)
+	}
+}
+
+func TestIssueCreateWarnsWithoutIssueTypes(t *testing.T) {
+	client, _ := setupCommandTest(t)
+	client.DisableIssueTypes("acme")
+
+	args := append([]string{"issue", "create", "--type", "task", "--title", "Login endpoint"}, taskFields...)
+	var runErr error
+	output := captureStdout(t, func() {
+		runErr = executeCommand(t, client, args...)
+	})
+	if runErr != nil {
+		t.Fatalf("issue create failed: %v\n%s", runErr, output)
+	}
+
+	if _, ok := client.Issue("acme", "pm", 3); !ok {
+		t.Fatalf("issue was not created:\n%s", output)
+	}
+	for _, want := range []string{"could not ensure issue type 'Task'", "Issue types must be enabled in the organization settings"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output lacks %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/pkg/issue"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editTitle  string
+	editType   string
+	editFields []string // Format: "fieldname=value"
+)
+
+var issueEditCmd = &cobra.Command{
+	Use:   "edit <issue>",
+	Short: "Edit the title or template fields of an issue",
+	Long: `Edit an existing issue without touching its markdown by hand.
+
+The body is parsed back into template fields using the template of the issue's
+type, the given fields are validated and replaced, and the body is written again
+with the same '### <label>' structure. Sections the template doesn't know are kept.
+An empty value removes an optional field.
+
+Examples:
+  # Fix the acceptance criteria of task #48
+  gh project-management issue edit 48 \
+    --field acceptance_criteria="- Returns 201 on success"
+
+  # Rename an issue in a team repository
+  gh project-management issue edit Zytera/backend#27 --title "Implement login endpoint"
+
+  # Issues without an issue type need the template type
+  gh project-management issue edit 12 --type bug --field severity=High`,
+	Args: cobra.ExactArgs(1),
+	RunE: runIssueEdit,
+}
+
+func runIssueEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	fields, err := parseFieldFlags(editFields)
+	if err != nil {
+		return err
+	}
+	if editTitle == "" && len(fields) == 0 {
+		return fmt.Errorf("nothing to edit: use --title or --field")
+	}
+
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := issue.EditIssue(ctx, client, issue.EditIssueParams{
+		Config:    cfg,
+		Ref:       args[0],
+		IssueType: editType,
+		Title:     editTitle,
+		Fields:    fields,
+	})
+	if err != nil {
+		return err
+	}
+
+	ref := fmt.Sprintf("%s#%d", result.Issue.Repo, result.Issue.Number)
+	if !result.Changed() {
+		fmt.Printf("✓ %s is already up to date\n", ref)
+		return nil
+	}
+
+	fmt.Printf("✓ Updated %s (%s template)\n", ref, result.IssueType)
+	if result.TitleChanged {
+		fmt.Printf("  ✓ Title: %s\n", editTitle)
+	}
+	for _, id := range result.ChangedFields {
+		if fields[id] == "" {
+			fmt.Printf("  ✓ %s: removed\n", id)
+		} else {
+			fmt.Printf("  ✓ %s\n", id)
+		}
+	}
+
+	if len(result.Unknown) > 0 {
+		labels := make([]string, 0, len(result.Unknown))
+		for _, section := range result.Unknown {
+			labels = append(labels, section.Label)
+		}
+		fmt.Printf("\n⚠️  Warning: Kept sections not in the template: %s\n", strings.Join(labels, ", "))
+	}
+	if len(result.Missing) > 0 {
+		fmt.Printf("\n⚠️  Warning: Required fields still missing: %s\n", strings.Join(result.Missing, ", "))
+		fmt.Printf("💡 Set them with: gh project-management issue edit %s --field <id>=<value>\n", args[0])
+	}
+
+	fmt.Printf("  URL: %s\n", result.Issue.URL)
+	return nil
+}
+
+func init() {
+	issueEditCmd.Flags().StringVar(&editTitle, "title", "", "New issue title")
+	issueEditCmd.Flags().StringVar(&editType, "type", "", "Template type (default: from the issue's type)")
+	issueEditCmd.Flags().StringArrayVar(&editFields, "field", []string{}, "Field values in format 'fieldname=value' (can be repeated)")
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFile       string
+	importType       string
+	importNoTransfer bool
+)
+
+var issueImportCmd = &cobra.Command{
+	Use:   "import --file <issues.csv|issues.json>",
+	Short: "Create issues in bulk from a CSV or JSON file",
+	Long: `Create one issue per row of a CSV file (with a header row) or per object of a
+JSON array.
+
+Columns:
+  type        Template type (or use --type for rows without one)
+  title       Issue title; optional if the template has a title pattern
+  team        Team field; the issue is transferred to the team's repository
+  priority    Priority field
+  parent      Parent issue (#12, owner/repo#12) or key of an earlier row
+  depends_on  Blocking issues or keys of earlier rows, separated by commas
+  key         Optional name for other rows to refer to this one
+  <field id>  Any other column is a template field (see 'issue create --show-fields')
+
+Every row is validated against its template before anything is created; if any
+row is invalid, the report lists the problems and no issue is created. Issues
+are created in file order, linked and marked as blocked in the default
+repository, and transferred to their team's repository at the end.
+
+Examples:
+  # Tasks from a planning spreadsheet
+  gh project-management issue import --file issues.csv --type task
+
+  # Create everything in the default repository
+  gh project-management issue import --file issues.json --no-transfer`,
+	Args: cobra.NoArgs,
+	RunE: runIssueImport,
+}
+
+func runIssueImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	rows, err := project.ReadImportFile(importFile)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no rows to import in %s", importFile)
+	}
+	for i := range rows {
+		if rows[i].Type == "" {
+			rows[i].Type = importType
+		}
+	}
+
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	service := project.New(client, cfg, nil)
+
+	// Report every invalid row at once, before anything is created
+	if invalid := service.ValidateImport(ctx, rows).Invalid(); len(invalid) > 0 {
+		fmt.Printf("✗ %d of %d rows are invalid, no issue was created:\n\n", len(invalid), len(rows))
+		for _, row := range invalid {
+			fmt.Printf("  row %d (%s): %v\n", row.Row, orDash(row.Title), row.Err)
+		}
+		return fmt.Errorf("invalid rows in %s", importFile)
+	}
+
+	fmt.Printf("🚀 Importing %d issues from %s...\n", len(rows), importFile)
+	result, err := service.Import(ctx, rows, !importNoTransfer)
+	printImportReport(result)
+	if err != nil {
+		if result.Created() > 0 {
+			fmt.Printf("\n⚠️  %d issues were created and not transferred; remove their rows before importing again\n", result.Created())
+		}
+		return err
+	}
+
+	warnings := 0
+	for _, row := range result.Rows {
+		for _, warning := range row.Issue.Warnings {
+			if warnings == 0 {
+				fmt.Println()
+			}
+			fmt.Printf("⚠️  Warning (row %d): %v\n", row.Row, warning)
+			if hint := gh.Hint(warning); hint != "" {
+				fmt.Printf("💡 %s\n", hint)
+			}
+			warnings++
+		}
+	}
+
+	fmt.Printf("\n✓ Created %d issues", result.Created())
+	if warnings > 0 {
+		fmt.Printf(" (%d warnings)", warnings)
+	}
+	fmt.Println()
+	return nil
+}
+
+// printImportReport prints one line per row: the created issue, or why the row failed
+func printImportReport(result *project.ImportResult) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ROW\tTYPE\tTITLE\tRESULT")
+	for _, row := range result.Rows {
+		var status string
+		switch {
+		case row.Err != nil && row.Issue != nil:
+			status = fmt.Sprintf("✗ %s created, but %v", row.Issue.Ref, row.Err)
+		case row.Err != nil:
+			status = "✗ " + row.Err.Error()
+		case row.Issue != nil && row.Issue.Transferred:
+			status = fmt.Sprintf("✓ %s (transferred)", row.Issue.Ref)
+		case row.Issue != nil:
+			status = "✓ " + row.Issue.Ref.String()
+		default:
+			status = "- not created"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", row.Row, orDash(row.Type), truncate(row.Title, 40), status)
+	}
+	w.Flush()
+}
+
+func init() {
+	issueImportCmd.Flags().StringVarP(&importFile, "file", "f", "", "CSV or JSON file to import")
+	issueImportCmd.Flags().StringVar(&importType, "type", "", "Issue type for rows without a type column")
+	issueImportCmd.Flags().BoolVar(&importNoTransfer, "no-transfer", false, "Don't transfer issues to their team's repository")
+	issueImportCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/pkg/issue"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listTeam     string
+	listPriority string
+	listStatus   string
+	listType     string
+	listRepo     string
+	listState    string
+	listAssignee string
+	listParent   string
+	listSort     string
+	listReverse  bool
+	listLimit    int
+)
+
+// maxTitleWidth is the number of title characters shown in the issue table
+const maxTitleWidth = 50
+
+var issueListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List issues in the project",
+	Long: `List the issues in the current context's project with their project field values.
+
+Issues in every repository that belongs to the project are listed, not just the
+default repository. Filters can be combined and ignore case.
+
+Examples:
+  # Open issues, sorted by repository and number
+  gh project-management issue list
+
+  # Critical and high priority backend work, most urgent first
+  gh project-management issue list --team Backend --sort priority
+
+  # My open tasks
+  gh project-management issue list --type task --assignee @me
+
+  # Every sub-issue of epic #44, open or closed
+  gh project-management issue list --parent 44 --state all
+
+  # The 10 most recently updated issues in the web repository
+  gh project-management issue list --repo web --sort updated --limit 10`,
+	Args: cobra.NoArgs,
+	RunE: runIssueList,
+}
+
+func runIssueList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, err := issue.ListIssues(ctx, client, issue.ListParams{
+		Config:   cfg,
+		Team:     listTeam,
+		Priority: listPriority,
+		Status:   listStatus,
+		Type:     listType,
+		Repo:     listRepo,
+		State:    listState,
+		Assignee: listAssignee,
+		Parent:   listParent,
+		Sort:     listSort,
+		Reverse:  listReverse,
+		Limit:    listLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No issues match the filters.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ISSUE\tTITLE\tTYPE\tSTATE\tTEAM\tPRIORITY\tSTATUS\tASSIGNEES")
+	for _, item := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			displayIssueRef(cfg, item),
+			truncate(item.Issue.Title, maxTitleWidth),
+			orDash(itemType(item)),
+			strings.ToLower(item.State),
+			orDash(item.Fields["Team"]),
+			orDash(item.Fields["Priority"]),
+			orDash(item.Fields["Status"]),
+			orDash(strings.Join(item.Assignees, ", ")),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\nShowing %d issue(s)\n", len(items))
+	return nil
+}
+
+// displayIssueRef shortens references to the context owner's repositories
+func displayIssueRef(cfg *config.Config, item gh.ProjectItem) string {
+	if item.Owner == cfg.Owner {
+		return fmt.Sprintf("%s#%d", item.Repo, item.Issue.Number)
+	}
+	return item.Ref()
+}
+
+// itemType returns the issue type, or the "Type" project field if no type is set
+func itemType(item gh.ProjectItem) string {
+	if item.IssueType != "" {
+		return item.IssueType
+	}
+	return item.Fields["Type"]
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func init() {
+	issueListCmd.Flags().StringVar(&listTeam, "team", "", "Filter by Team field")
+	issueListCmd.Flags().StringVar(&listPriority, "priority", "", "Filter by Priority field")
+	issueListCmd.Flags().StringVar(&listStatus, "status", "", "Filter by Status field")
+	issueListCmd.Flags().StringVarP(&listType, "type", "t", "", "Filter by issue type (epic, story, task, bug, feature or a custom type)")
+	issueListCmd.Flags().StringVar(&listRepo, "repo", "", "Filter by repository (name or owner/name)")
+	issueListCmd.Flags().StringVarP(&listState, "state", "s", "open", "Filter by state: open, closed or all")
+	issueListCmd.Flags().StringVarP(&listAssignee, "assignee", "a", "", "Filter by assignee login (@me for yourself)")
+	issueListCmd.Flags().StringVar(&listParent, "parent", "", "Filter by parent issue (number or owner/repo#number)")
+	issueListCmd.Flags().StringVar(&listSort, "sort", "", fmt.Sprintf("Sort by: %s (default repo)", strings.Join(issue.SortKeys, ", ")))
+	issueListCmd.Flags().BoolVar(&listReverse, "reverse", false, "Reverse the sort order")
+	issueListCmd.Flags().IntVarP(&listLimit, "limit", "L", 30, "Maximum number of issues to show (0 for all)")
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/pkg/issue"
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+)
+
+var (
+	promoteType string
+	promoteRepo string
+)
+
+var issuePromoteCmd = &cobra.Command{
+	Use:   "promote [draft]",
+	Short: "Convert a project draft issue into an issue",
+	Long: `Convert a draft issue of the project, e.g. one added with 'issue create --draft',
+into an issue in a repository.
+
+The draft is given by its project item ID or its title. Without one, you can
+pick it from the project's drafts.
+
+The project item is kept, so the issue keeps the draft's field values. The
+issue is created in the team repository of the draft's Team field (see
+'context add --team-repos'), or in the default repository if it has none, so
+no transfer is needed. The issue type is set from the type stored in drafts
+created with 'issue create --draft', or from --type, and the template's labels
+are added.
+
+Examples:
+  # Pick the draft interactively
+  gh project-management issue promote
+
+  # By title or item ID
+  gh project-management issue promote "Implement API"
+  gh project-management issue promote PVTI_lADOBx...
+
+  # A draft added in the web UI has no stored type
+  gh project-management issue promote "Login fails on Safari" --type bug
+
+  # Create it in a specific repository
+  gh project-management issue promote "Implement API" --repo web-app`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIssuePromote,
+}
+
+func runIssuePromote(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var draft string
+	if len(args) > 0 {
+		draft = args[0]
+	} else {
+		draft, err = promptForDraft(ctx, client, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := issue.PromoteDraft(ctx, client, issue.PromoteDraftParams{
+		Config:    cfg,
+		Draft:     draft,
+		IssueType: promoteType,
+		Repo:      promoteRepo,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to promote draft issue: %w", err)
+	}
+
+	fmt.Printf("✓ Promoted to %s/%s#%d: %s\n", result.Owner, result.Repo, result.Issue.Number, result.Issue.Title)
+	fmt.Printf("  URL: %s\n", result.Issue.URL)
+	fmt.Printf("  Type: %s\n", result.IssueType)
+	if len(result.Labels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(result.Labels, ", "))
+	}
+	if len(result.Fields) > 0 {
+		names := make([]string, 0, len(result.Fields))
+		for name := range result.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  ✓ %s: %s\n", name, result.Fields[name])
+		}
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠️  Warning: %v\n", warning)
+		if hint := gh.Hint(warning); hint != "" {
+			fmt.Printf("💡 %s\n", hint)
+		}
+	}
+
+	return nil
+}
+
+// promptForDraft asks the user to pick one of the project's draft issues
+func promptForDraft(ctx context.Context, client gh.Client, cfg *config.Config) (string, error) {
+	drafts, err := issue.ListDrafts(ctx, client, cfg)
+	if err != nil {
+		return "", err
+	}
+	if len(drafts) == 0 {
+		return "", fmt.Errorf("the project has no draft issues")
+	}
+
+	options := make([]huh.Option[string], len(drafts))
+	for i, d := range drafts {
+		label := d.Title
+		if team := d.Fields["Team"]; team != "" {
+			label += fmt.Sprintf(" (%s)", team)
+		}
+		options[i] = huh.NewOption(label, d.ID)
+	}
+
+	var selected string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("📝 Draft Issue").
+				Description("Select the draft to turn into an issue").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return "", fmt.Errorf("failed to select draft issue: %w", err)
+	}
+	return selected, nil
+}
+
+func init() {
+	issuePromoteCmd.Flags().StringVar(&promoteType, "type", "", "Issue type (defaults to the type stored in the draft)")
+	issuePromoteCmd.Flags().StringVar(&promoteRepo, "repo", "", "Repository to create the issue in (defaults to the Team field's repository)")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/pkg/issue"
+	"github.com/spf13/cobra"
+)
+
+var viewNoBody bool
+
+var issueViewCmd = &cobra.Command{
+	Use:   "view <issue>",
+	Short: "Show an issue with its hierarchy and project fields",
+	Long: `Show an issue's title, state, issue type and body, its field values in the
+current context's project, its parent and sub-issues, and the issues it is
+blocked by or blocking.
+
+Use it to check that 'issue create --parent --depends-on --team' did everything
+without opening the web UI.
+
+Examples:
+  # Issue #48 in the default repository
+  gh project-management issue view 48
+
+  # An issue in another repository, e.g. after a transfer
+  gh project-management issue view Zytera/backend#27
+
+  # Only relationships and fields
+  gh project-management issue view 44 --no-body`,
+	Args: cobra.ExactArgs(1),
+	RunE: runIssueView,
+}
+
+func runIssueView(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	view, err := issue.ViewIssue(ctx, client, cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Header
+	fmt.Printf("\033[1m%s\033[0m %s#%d\n", view.Title, view.Repo, view.Number)
+	details := []string{strings.ToLower(view.State)}
+	if view.IssueType != "" {
+		details = append(details, view.IssueType)
+	}
+	if len(view.Assignees) > 0 {
+		details = append(details, "assigned to "+strings.Join(view.Assignees, ", "))
+	}
+	if len(view.Labels) > 0 {
+		details = append(details, "labels: "+strings.Join(view.Labels, ", "))
+	}
+	if view.Milestone != "" {
+		details = append(details, "milestone: "+view.Milestone)
+	}
+	fmt.Println(strings.Join(details, " · "))
+	fmt.Println(view.URL)
+
+	// Project fields
+	fmt.Printf("\nProject fields (%s #%s):\n", cfg.ProjectName, cfg.ProjectID)
+	if view.ProjectItem == nil {
+		fmt.Println("  ⚠️  Not in the project")
+		fmt.Printf("  💡 Set a field to add it: gh project-management field set %s --priority <priority>\n", args[0])
+	} else {
+		printProjectFields(view.ProjectItem.Fields)
+	}
+
+	// Hierarchy
+	fmt.Println("\nParent:")
+	if view.Parent == nil {
+		fmt.Println("  none")
+	} else {
+		printIssueSummary(cfg, *view.Parent)
+	}
+	printIssueSummaries(cfg, "Sub-issues", view.SubIssues)
+
+	// Dependencies
+	printIssueSummaries(cfg, "Blocked by", view.BlockedBy)
+	printIssueSummaries(cfg, "Blocking", view.Blocking)
+
+	if !viewNoBody {
+		fmt.Println("\nBody:")
+		body := strings.TrimSpace(view.Body)
+		if body == "" {
+			body = "(empty)"
+		}
+		fmt.Println(body)
+	}
+
+	return nil
+}
+
+// printProjectFields prints field values sorted by name, skipping the built-in Title field
+func printProjectFields(fields map[string]string) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if name != "Title" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		fmt.Println("  none set")
+		return
+	}
+
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	for _, name := range names {
+		fmt.Printf("  %-*s  %s\n", width+1, name+":", fields[name])
+	}
+}
+
+func printIssueSummaries(cfg *config.Config, heading string, issues []gh.IssueSummary) {
+	if len(issues) == 0 {
+		fmt.Printf("\n%s: none\n", heading)
+		return
+	}
+
+	closed := 0
+	for _, summary := range issues {
+		if summary.State == "CLOSED" {
+			closed++
+		}
+	}
+	fmt.Printf("\n%s (%d/%d closed):\n", heading, closed, len(issues))
+	for _, summary := range issues {
+		printIssueSummary(cfg, summary)
+	}
+}
+
+func printIssueSummary(cfg *config.Config, summary gh.IssueSummary) {
+	mark := "○"
+	if summary.State == "CLOSED" {
+		mark = "✓"
+	}
+	ref := summary.Ref()
+	if summary.Owner == cfg.Owner {
+		ref = fmt.Sprintf("%s#%d", summary.Repo, summary.Number)
+	}
+	fmt.Printf("  %s %s %s (%s)\n", mark, ref, summary.Title, strings.ToLower(summary.State))
+}
+
+func init() {
+	issueViewCmd.Flags().BoolVar(&viewNoBody, "no-body", false, "Don't print the issue body")
+}
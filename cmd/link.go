@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/Zytera/gh-project-management/internal/config"
@@ -59,7 +58,7 @@ Examples:
 }
 
 func runLinkAdd(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -67,16 +66,21 @@ func runLinkAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	parentIssueRef := args[0]
 	childIssueRef := args[1]
 
 	// Parse issue references
-	_, _, parentNumber, err := gh.ParseIssueReference(parentIssueRef, cfg.Owner, cfg.DefaultRepo)
+	_, _, parentNumber, err := gh.ParseIssueReference(parentIssueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 	if err != nil {
 		return fmt.Errorf("invalid parent issue reference: %w", err)
 	}
 
-	_, _, childNumber, err := gh.ParseIssueReference(childIssueRef, cfg.Owner, cfg.DefaultRepo)
+	_, _, childNumber, err := gh.ParseIssueReference(childIssueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 	if err != nil {
 		return fmt.Errorf("invalid child issue reference: %w", err)
 	}
@@ -84,7 +88,7 @@ func runLinkAdd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Linking issue #%d as child of issue #%d...\n", childNumber, parentNumber)
 
 	// Add the sub-issue relationship
-	err = gh.AddSubIssue(ctx, cfg.Owner, cfg.DefaultRepo, parentNumber, childNumber)
+	err = client.AddSubIssue(ctx, cfg.Owner, cfg.DefaultRepo, parentNumber, childNumber)
 	if err != nil {
 		return fmt.Errorf("failed to link issues: %w", err)
 	}
@@ -94,7 +98,7 @@ func runLinkAdd(cmd *cobra.Command, args []string) error {
 }
 
 func runLinkRemove(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -102,16 +106,21 @@ func runLinkRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	parentIssueRef := args[0]
 	childIssueRef := args[1]
 
 	// Parse issue references
-	_, _, parentNumber, err := gh.ParseIssueReference(parentIssueRef, cfg.Owner, cfg.DefaultRepo)
+	_, _, parentNumber, err := gh.ParseIssueReference(parentIssueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 	if err != nil {
 		return fmt.Errorf("invalid parent issue reference: %w", err)
 	}
 
-	_, _, childNumber, err := gh.ParseIssueReference(childIssueRef, cfg.Owner, cfg.DefaultRepo)
+	_, _, childNumber, err := gh.ParseIssueReference(childIssueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 	if err != nil {
 		return fmt.Errorf("invalid child issue reference: %w", err)
 	}
@@ -119,7 +128,7 @@ func runLinkRemove(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Removing link between #%d and #%d...\n", childNumber, parentNumber)
 
 	// Remove the sub-issue relationship
-	err = gh.RemoveSubIssue(ctx, cfg.Owner, cfg.DefaultRepo, parentNumber, childNumber)
+	err = client.RemoveSubIssue(ctx, cfg.Owner, cfg.DefaultRepo, parentNumber, childNumber)
 	if err != nil {
 		return fmt.Errorf("failed to remove link: %w", err)
 	}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var rateLimitCmd = &cobra.Command{
+	Use:   "rate-limit",
+	Short: "Show the remaining GitHub API budget",
+	Long: `Show how much of the GitHub GraphQL API rate limit is left.
+
+All commands retry automatically when GitHub reports a secondary rate limit
+or a transient error (502/503/504 on read-only requests), waiting as long as
+GitHub asks for up to 5 minutes, and warn once less than 10% of the budget
+is left. Use this command to check the budget before running large operations.
+
+Examples:
+  gh project-management rate-limit`,
+	Args: cobra.NoArgs,
+	RunE: runRateLimit,
+}
+
+func runRateLimit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Use the current context's host if there is one
+	host := ""
+	if cfg, err := config.Load(); err == nil {
+		host = cfg.Host
+	}
+
+	client, err := newHostClient(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	limit, err := client.GetRateLimit(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("GraphQL budget: %d/%d points remaining (%d used)\n", limit.Remaining, limit.Limit, limit.Used)
+	fmt.Printf("Resets at:      %s (in %s)\n", limit.ResetAt.Local().Format(time.Kitchen), time.Until(limit.ResetAt).Round(time.Second))
+
+	if limit.Low() {
+		fmt.Println("⚠️  Warning: less than 10% of the budget is left")
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(rateLimitCmd)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/gh/cache"
 	"github.com/spf13/cobra"
 )
 
@@ -23,14 +26,14 @@ Use 'gh project-management context add <name>' to configure your first project.`
 
 func Execute() int {
 	// Check if the command being executed needs configuration
-	// Commands that don't need config: context, help, version
+	// Commands that don't need config: context, cache, doctor, rate-limit, help, version
 	args := os.Args[1:]
 	needsConfig := true
 
 	if len(args) > 0 {
 		cmd := args[0]
 		// Commands that don't require configuration
-		if cmd == "context" || cmd == "help" || cmd == "--help" || cmd == "-h" || cmd == "version" || cmd == "--version" || cmd == "-v" {
+		if cmd == "context" || cmd == "cache" || cmd == "doctor" || cmd == "rate-limit" || cmd == "help" || cmd == "--help" || cmd == "-h" || cmd == "version" || cmd == "--version" || cmd == "-v" {
 			needsConfig = false
 		}
 	}
@@ -52,7 +55,72 @@ func Execute() int {
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		if hint := gh.Hint(err); hint != "" {
+			fmt.Fprintf(os.Stderr, "💡 %s\n", hint)
+		}
 		return 1
 	}
 	return 0
 }
+
+// newClient returns the GitHub client injected into ctx under gh.ClientKey,
+// falling back to a client built with the gh CLI's token.
+// When ctx carries a context configuration, the client is built for that
+// context's host and metadata lookups are cached on disk for that context
+// (except while recording or replaying API traffic).
+func newClient(ctx context.Context) (gh.Client, error) {
+	cfg, _ := ctx.Value(config.ConfigKey{}).(*config.Config)
+	if cfg == nil {
+		return newHostClient(ctx, "")
+	}
+
+	client, err := newHostClient(ctx, cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+	if _, isAPIClient := client.(*gh.APIClient); !isAPIClient || cfg.Name == "" {
+		return client, nil
+	}
+	if os.Getenv(gh.RecordEnvVar) != "" || os.Getenv(gh.ReplayEnvVar) != "" {
+		return client, nil
+	}
+
+	cachePath, err := config.GetCachePath(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(client, cachePath), nil
+}
+
+// newHostClient returns the GitHub client injected into ctx under gh.ClientKey,
+// falling back to an uncached client for host (empty for the gh CLI default)
+// that reports retries on stderr
+func newHostClient(ctx context.Context, host string) (gh.Client, error) {
+	if client, ok := ctx.Value(gh.ClientKey{}).(gh.Client); ok {
+		return client, nil
+	}
+
+	client, err := gh.NewDefaultClient(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+	client.OnRetry(printRetry)
+	client.OnLowRateLimit(printLowRateLimit)
+	return client, nil
+}
+
+// printRetry tells the user that a request waits for a rate limit or a
+// transient error before being retried
+func printRetry(message string) {
+	fmt.Fprintf(os.Stderr, "⏳ %s\n", message)
+}
+
+// printLowRateLimit warns that the rate limit budget is nearly used up, so
+// long-running commands may have to wait for it to reset
+func printLowRateLimit(limit gh.RateLimit) {
+	fmt.Fprintf(os.Stderr, "⚠️  Warning: only %d of %d %s API points are left until %s", limit.Remaining, limit.Limit, limit.Resource, limit.ResetAt.Local().Format(time.Kitchen))
+	if limit.Cost > 0 {
+		fmt.Fprintf(os.Stderr, " (the last bulk query cost %d)", limit.Cost)
+	}
+	fmt.Fprintln(os.Stderr)
+}
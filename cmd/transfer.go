@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/Zytera/gh-project-management/internal/config"
@@ -49,7 +48,7 @@ Examples:
 }
 
 func runTransferIssue(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -57,9 +56,14 @@ func runTransferIssue(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Parse issue reference
 	issueRef := args[0]
-	_, _, issueNumber, err := gh.ParseIssueReference(issueRef, cfg.Owner, cfg.DefaultRepo)
+	_, _, issueNumber, err := gh.ParseIssueReference(issueRef, cfg.Host, cfg.Owner, cfg.DefaultRepo)
 	if err != nil {
 		return fmt.Errorf("invalid issue reference: %w", err)
 	}
@@ -79,7 +83,7 @@ func runTransferIssue(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Transfer the issue
-	_, err = gh.TransferIssue(ctx, issueNumber, cfg.Owner, targetRepo, sourceRepo)
+	_, err = client.TransferIssue(ctx, issueNumber, cfg.Owner, targetRepo, sourceRepo)
 	if err != nil {
 		return fmt.Errorf("failed to transfer issue: %w", err)
 	}
@@ -26,6 +26,7 @@ const (
 
 // Context represents a project configuration
 type Context struct {
+	Host        string            `yaml:"host,omitempty"` // GitHub host; empty uses the gh CLI default (github.com or GH_HOST)
 	OwnerType   OwnerType         `yaml:"owner_type"`
 	Owner       string            `yaml:"owner"`
 	ProjectID   string            `yaml:"project_id"`
@@ -36,6 +37,8 @@ type Context struct {
 
 // Config is the active context configuration (for backwards compatibility in code)
 type Config struct {
+	Name        string // Context name
+	Host        string // GitHub host; empty uses the gh CLI default
 	OwnerType   OwnerType
 	Owner       string
 	ProjectID   string
@@ -59,6 +62,21 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(configDir, "config.yaml"), nil
 }
 
+// GetCachePath returns the path to the metadata cache file of a context
+func GetCachePath(contextName string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting home directory: %w", err)
+	}
+
+	cacheDir := filepath.Join(homeDir, ".config", "gh-project-management", "cache")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	return filepath.Join(cacheDir, contextName+".json"), nil
+}
+
 // Load reads the global config and returns the active context configuration
 func Load() (*Config, error) {
 	configPath, err := GetConfigPath()
@@ -91,6 +109,8 @@ func Load() (*Config, error) {
 	}
 
 	config := &Config{
+		Name:        globalConfig.CurrentContext,
+		Host:        ctx.Host,
 		OwnerType:   ctx.OwnerType,
 		Owner:       ctx.Owner,
 		ProjectID:   ctx.ProjectID,
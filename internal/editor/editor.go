@@ -0,0 +1,106 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	ghconfig "github.com/cli/go-gh/v2/pkg/config"
+)
+
+// Command returns the editor to run, looked up like the gh CLI does: the
+// GH_EDITOR environment variable, gh's "editor" setting, then VISUAL and
+// EDITOR. It falls back to nano, or notepad on Windows.
+func Command() string {
+	if editor := os.Getenv("GH_EDITOR"); editor != "" {
+		return editor
+	}
+	if cfg, err := ghconfig.Read(nil); err == nil {
+		if editor, err := cfg.Get([]string{"editor"}); err == nil && editor != "" {
+			return editor
+		}
+	}
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(name); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "nano"
+}
+
+// Edit writes content to a temporary file named after pattern (see
+// os.CreateTemp), opens it in the editor and returns the saved content.
+// The editor command may have arguments and quoted paths, like
+// "code --wait" or "'/Applications/Sublime Text.app/Contents/SharedSupport/bin/subl' -w".
+func Edit(pattern, content string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	editor := strings.TrimSpace(Command())
+	if editor == "" {
+		return "", fmt.Errorf("no editor configured")
+	}
+	cmd := editorCommand(editor, file.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor '%s' failed: %w", editor, err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return string(edited), nil
+}
+
+// editorCommand returns the command that opens path in editor. Like git, it
+// runs the editor through the shell as `editor "$@"`, so quoting in the
+// setting works as it does on the command line. Windows has no sh, so there
+// the setting is split on spaces outside double quotes.
+func editorCommand(editor, path string) *exec.Cmd {
+	if runtime.GOOS != "windows" {
+		return exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+	}
+
+	var args []string
+	var arg strings.Builder
+	quoted, started := false, false
+	for _, r := range editor {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case r == ' ' && !quoted:
+			if started {
+				args = append(args, arg.String())
+				arg.Reset()
+				started = false
+			}
+		default:
+			arg.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, arg.String())
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
@@ -0,0 +1,47 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestEditRunsQuotedEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test editor is a shell script")
+	}
+
+	// The editor appends its arguments, so the test sees what it was called with
+	dir := filepath.Join(t.TempDir(), "Sublime Text.app")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "subl")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nfile=\"$2\"\necho \"flag $1\" >> \"$file\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		editor string
+	}{
+		{name: "double quotes", editor: `"` + script + `" -w`},
+		{name: "single quotes", editor: `'` + script + `' -w`},
+		{name: "escaped spaces", editor: filepath.Join(filepath.Dir(dir), `Sublime\ Text.app`, "subl") + " -w"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_EDITOR", tt.editor)
+
+			edited, err := Edit("edit-*.md", "### Notes\n")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "### Notes\nflag -w\n"; edited != want {
+				t.Errorf("Edit = %q, want %q", edited, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Package cache wraps a gh.Client with an on-disk cache of project and
+// issue type metadata, so commands don't resolve it again on every run.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+)
+
+// Time-to-live of each kind of cached lookup
+const (
+	ProjectNodeIDTTL = 7 * 24 * time.Hour
+	ProjectFieldsTTL = time.Hour
+	IssueTypesTTL    = time.Hour
+)
+
+// entry is a cached value and the time it was fetched
+type entry[T any] struct {
+	Value     T         `json:"value"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+func (e entry[T]) fresh(ttl time.Duration) bool {
+	return time.Since(e.FetchedAt) < ttl
+}
+
+// data is the on-disk format of the cache
+type data struct {
+	ProjectNodeIDs map[string]entry[string]                      `json:"project_node_ids"`
+	ProjectFields  map[string]entry[[]gh.Field]                  `json:"project_fields"`
+	IssueTypes     map[string]entry[[]templates.IssueTypeConfig] `json:"issue_types"`
+}
+
+// Client is a gh.Client that caches project node IDs, project fields and
+// organization issue types in a file. Mutations that change fields or issue
+// types invalidate the matching entries; every other call is passed through.
+type Client struct {
+	gh.Client
+
+	mu   sync.Mutex
+	path string
+	data data
+}
+
+var _ gh.Client = (*Client)(nil)
+var _ gh.Invalidator = (*Client)(nil)
+
+// New wraps client with a cache stored at path.
+// A missing or unreadable cache file starts an empty cache.
+func New(client gh.Client, path string) *Client {
+	c := &Client{Client: client, path: path}
+
+	if raw, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(raw, &c.data)
+	}
+	if c.data.ProjectNodeIDs == nil {
+		c.data.ProjectNodeIDs = make(map[string]entry[string])
+	}
+	if c.data.ProjectFields == nil {
+		c.data.ProjectFields = make(map[string]entry[[]gh.Field])
+	}
+	if c.data.IssueTypes == nil {
+		c.data.IssueTypes = make(map[string]entry[[]templates.IssueTypeConfig])
+	}
+	return c
+}
+
+// Clear removes the cache file at path
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cache %s: %w", path, err)
+	}
+	return nil
+}
+
+// save writes the cache to disk. Failing to persist only costs extra
+// round trips on the next run, so errors are ignored. Callers hold c.mu.
+func (c *Client) save() {
+	raw, err := json.MarshalIndent(c.data, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path, raw, 0644)
+}
+
+// GetProjectNodeID returns the cached node ID of an organization project
+func (c *Client) GetProjectNodeID(ctx context.Context, org string, projectNumber int) (string, error) {
+	return c.projectNodeID(fmt.Sprintf("org/%s/%d", org, projectNumber), func() (string, error) {
+		return c.Client.GetProjectNodeID(ctx, org, projectNumber)
+	})
+}
+
+// GetUserProjectNodeID returns the cached node ID of a user project
+func (c *Client) GetUserProjectNodeID(ctx context.Context, projectNumber int) (string, error) {
+	return c.projectNodeID(fmt.Sprintf("user/%d", projectNumber), func() (string, error) {
+		return c.Client.GetUserProjectNodeID(ctx, projectNumber)
+	})
+}
+
+func (c *Client) projectNodeID(key string, fetch func() (string, error)) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.data.ProjectNodeIDs[key]
+	c.mu.Unlock()
+	if ok && cached.fresh(ProjectNodeIDTTL) {
+		return cached.Value, nil
+	}
+
+	id, err := fetch()
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data.ProjectNodeIDs[key] = entry[string]{Value: id, FetchedAt: time.Now()}
+	c.save()
+	return id, nil
+}
+
+// GetProjectFields returns the cached fields of a project
+func (c *Client) GetProjectFields(ctx context.Context, projectID string) ([]gh.Field, error) {
+	c.mu.Lock()
+	cached, ok := c.data.ProjectFields[projectID]
+	c.mu.Unlock()
+	if ok && cached.fresh(ProjectFieldsTTL) {
+		return cached.Value, nil
+	}
+
+	fields, err := c.Client.GetProjectFields(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data.ProjectFields[projectID] = entry[[]gh.Field]{Value: fields, FetchedAt: time.Now()}
+	c.save()
+	return fields, nil
+}
+
+// ListOrgIssueTypes returns the cached issue types of an organization
+func (c *Client) ListOrgIssueTypes(ctx context.Context, org string) ([]templates.IssueTypeConfig, error) {
+	c.mu.Lock()
+	cached, ok := c.data.IssueTypes[org]
+	c.mu.Unlock()
+	if ok && cached.fresh(IssueTypesTTL) {
+		return cached.Value, nil
+	}
+
+	issueTypes, err := c.Client.ListOrgIssueTypes(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data.IssueTypes[org] = entry[[]templates.IssueTypeConfig]{Value: issueTypes, FetchedAt: time.Now()}
+	c.save()
+	return issueTypes, nil
+}
+
+// CreateSingleSelectField creates the field and drops the project's cached fields
+func (c *Client) CreateSingleSelectField(ctx context.Context, projectID, fieldName string, options map[string]gh.FieldColor) (*gh.Field, error) {
+	defer c.InvalidateProjectFields(projectID)
+	return c.Client.CreateSingleSelectField(ctx, projectID, fieldName, options)
+}
+
+// AddOptionsToField adds the options and drops all cached project fields,
+// since the field ID alone doesn't tell which project it belongs to
+func (c *Client) AddOptionsToField(ctx context.Context, fieldID string, options map[string]gh.FieldColor) error {
+	defer c.invalidateAllProjectFields()
+	return c.Client.AddOptionsToField(ctx, fieldID, options)
+}
+
+// CreateIssueType creates the issue type and drops all cached issue types,
+// since the organization node ID doesn't match the login they are keyed by
+func (c *Client) CreateIssueType(ctx context.Context, orgID, name, description string) (*templates.IssueTypeConfig, error) {
+	defer c.invalidateAllIssueTypes()
+	return c.Client.CreateIssueType(ctx, orgID, name, description)
+}
+
+// InvalidateProjectFields drops the cached fields of a project
+func (c *Client) InvalidateProjectFields(projectID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data.ProjectFields, projectID)
+	c.save()
+}
+
+// InvalidateIssueTypes drops the cached issue types of an organization
+func (c *Client) InvalidateIssueTypes(org string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data.IssueTypes, org)
+	c.save()
+}
+
+func (c *Client) invalidateAllProjectFields() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data.ProjectFields = make(map[string]entry[[]gh.Field])
+	c.save()
+}
+
+func (c *Client) invalidateAllIssueTypes() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data.IssueTypes = make(map[string]entry[[]templates.IssueTypeConfig])
+	c.save()
+}
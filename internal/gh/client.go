@@ -0,0 +1,167 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Zytera/gh-project-management/internal/gh/replay"
+	"github.com/Zytera/gh-project-management/internal/templates"
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// ClientKey is the context key under which commands look up an injected Client
+type ClientKey struct{}
+
+// Client is the set of GitHub operations used by the commands and pkg/ packages.
+// APIClient talks to the real API; the fake package provides an in-memory backend.
+type Client interface {
+	// Users and organizations
+	GetCurrentUser() (string, error)
+	GetUserNodeID(ctx context.Context, login string) (string, error)
+	GetTokenScopes(ctx context.Context) ([]string, error)
+	ListOrganizations() ([]Organization, error)
+	GetOrgNodeID(ctx context.Context, org string) (string, error)
+
+	// Repositories
+	ListOrgRepositories(org string) ([]Repository, error)
+	ListUserRepositories() ([]Repository, error)
+	GetRepositoryPermission(ctx context.Context, owner, repo string) (string, error)
+	GetRepositoryNodeID(ctx context.Context, owner, repo string) (string, error)
+	GetTemplateFromRepo(ctx context.Context, owner, repo, issueType string) (*templates.IssueTemplate, string, error)
+
+	// Issues
+	CreateIssue(ctx context.Context, owner, repo, title, body string, opts CreateIssueOptions) (*Issue, error)
+	UpdateIssue(ctx context.Context, issueNodeID string, title, body *string) error
+	SetIssueType(ctx context.Context, issueNodeID, issueTypeID string) error
+	CloseIssue(ctx context.Context, issueNodeID string) error
+	DeleteIssue(ctx context.Context, issueNodeID string) error
+	GetIssueNodeID(ctx context.Context, owner, repo string, issueNumber int) (string, error)
+	GetIssueDetails(ctx context.Context, owner, repo string, issueNumber int) (*IssueDetails, error)
+	ListRecentIssues(ctx context.Context, owner, repo string, limit int) ([]Issue, error)
+	TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo, sourceRepo string) (int, error)
+
+	// Labels and milestones
+	ListLabels(ctx context.Context, owner, repo string) ([]Label, error)
+	CreateLabel(ctx context.Context, owner, repo string, label Label) (*Label, error)
+	AddLabelsToIssue(ctx context.Context, issueNodeID string, labelIDs []string) error
+	ListMilestones(ctx context.Context, owner, repo string) ([]Milestone, error)
+
+	// Issue relationships
+	AddSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error
+	RemoveSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error
+	AddBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error
+	RemoveBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error
+
+	// Issue types
+	ListOrgIssueTypes(ctx context.Context, org string) ([]templates.IssueTypeConfig, error)
+	CreateIssueType(ctx context.Context, orgID, name, description string) (*templates.IssueTypeConfig, error)
+
+	// Projects
+	ListOrgProjects(org string) ([]Project, error)
+	ListUserProjects() ([]Project, error)
+	GetProjectNodeID(ctx context.Context, org string, projectNumber int) (string, error)
+	GetUserProjectNodeID(ctx context.Context, projectNumber int) (string, error)
+	GetProjectFields(ctx context.Context, projectID string) ([]Field, error)
+	CreateSingleSelectField(ctx context.Context, projectID, fieldName string, options map[string]FieldColor) (*Field, error)
+	AddOptionsToField(ctx context.Context, fieldID string, options map[string]FieldColor) error
+	AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (string, error)
+	DeleteProjectItem(ctx context.Context, projectID, itemID string) error
+	AddDraftIssueToProject(ctx context.Context, projectID, title, body string, assigneeIDs []string) (string, error)
+	ListDraftIssues(ctx context.Context, projectID string) ([]DraftIssue, error)
+	ConvertDraftIssue(ctx context.Context, itemID, repositoryID string) (*Issue, error)
+	GetProjectItemID(ctx context.Context, projectID, issueNodeID string) (string, error)
+	ListProjectItems(ctx context.Context, projectID string) ([]ProjectItem, error)
+	UpdateProjectItemField(ctx context.Context, projectID, itemID, fieldID, optionID string) error
+
+	// Rate limits
+	GetRateLimit(ctx context.Context) (*RateLimit, error)
+}
+
+// Invalidator is implemented by clients that cache lookups. Helpers call it
+// when a cached lookup misses, so the next call fetches fresh data.
+type Invalidator interface {
+	InvalidateProjectFields(projectID string)
+	InvalidateIssueTypes(org string)
+}
+
+// APIClient implements Client on top of the go-gh GraphQL and REST clients.
+// Errors it returns are classified as *Error when the cause is recognized.
+type APIClient struct {
+	graphQL    *graphQLClient
+	issueTypes *graphQLClient
+	rest       *restClient
+	rateLimits *rateLimitTransport
+}
+
+// NewClient creates an APIClient from go-gh client options.
+// The transport is wrapped so rate limited and transient failures are retried.
+func NewClient(opts api.ClientOptions) (*APIClient, error) {
+	rateLimits := newRateLimitTransport(opts.Transport)
+	opts.Transport = rateLimits
+
+	graphQL, err := api.NewGraphQLClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
+	}
+
+	// The issue types API requires a feature header on every request
+	issueTypesOpts := opts
+	issueTypesOpts.Headers = map[string]string{
+		"GraphQL-Features": "issue_types",
+	}
+	for k, v := range opts.Headers {
+		issueTypesOpts.Headers[k] = v
+	}
+	if issueTypesOpts.Timeout == 0 {
+		issueTypesOpts.Timeout = 30 * time.Second
+	}
+
+	issueTypes, err := api.NewGraphQLClient(issueTypesOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL client with issue types headers: %w", err)
+	}
+
+	rest, err := api.NewRESTClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	return &APIClient{
+		graphQL:    &graphQLClient{client: graphQL},
+		issueTypes: &graphQLClient{client: issueTypes, issueTypes: true},
+		rest:       &restClient{client: rest},
+		rateLimits: rateLimits,
+	}, nil
+}
+
+// Environment variables selecting the record/replay transport
+const (
+	RecordEnvVar = "GH_PROJECT_MANAGEMENT_RECORD"
+	ReplayEnvVar = "GH_PROJECT_MANAGEMENT_REPLAY"
+)
+
+// NewDefaultClient creates an APIClient for host using the gh CLI's token for that host.
+// An empty host uses the gh CLI's default host (github.com, or GH_HOST if set).
+// If RecordEnvVar is set, all API traffic is recorded to that fixture file;
+// if ReplayEnvVar is set, responses are served from that fixture instead of GitHub.
+func NewDefaultClient(host string) (*APIClient, error) {
+	opts := api.ClientOptions{Host: host}
+
+	if path := os.Getenv(ReplayEnvVar); path != "" {
+		fixture, err := replay.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Host == "" {
+			opts.Host = "github.com"
+		}
+		opts.AuthToken = "replay"
+		opts.Transport = replay.NewReplayer(fixture)
+	} else if path := os.Getenv(RecordEnvVar); path != "" {
+		opts.Transport = replay.NewRecorder(path, nil)
+	}
+
+	return NewClient(opts)
+}
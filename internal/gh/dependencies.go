@@ -3,25 +3,22 @@ package gh
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 
-	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
 )
 
 // AddBlockedBy establishes a dependency where blockedIssue is blocked by blockingIssue
-func AddBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) AddBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error {
 	// Get issue node IDs for both issues
-	blockedIssueNodeID, err := GetIssueNodeID(ctx, *client, owner, repo, blockedIssueNumber)
+	blockedIssueNodeID, err := c.GetIssueNodeID(ctx, owner, repo, blockedIssueNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get blocked issue node ID: %w", err)
 	}
 
-	blockingIssueNodeID, err := GetIssueNodeID(ctx, *client, owner, repo, blockingIssueNumber)
+	blockingIssueNodeID, err := c.GetIssueNodeID(ctx, owner, repo, blockingIssueNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get blocking issue node ID: %w", err)
 	}
@@ -57,7 +54,7 @@ func AddBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, b
 		} `json:"addBlockedBy"`
 	}
 
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err = c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return fmt.Errorf("failed to add blocked-by relationship: %w", err)
 	}
@@ -65,8 +62,55 @@ func AddBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, b
 	return nil
 }
 
+// RemoveBlockedBy removes the dependency of blockedIssue on blockingIssue
+func (c *APIClient) RemoveBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error {
+	// Get issue node IDs for both issues
+	blockedIssueNodeID, err := c.GetIssueNodeID(ctx, owner, repo, blockedIssueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get blocked issue node ID: %w", err)
+	}
+
+	blockingIssueNodeID, err := c.GetIssueNodeID(ctx, owner, repo, blockingIssueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get blocking issue node ID: %w", err)
+	}
+
+	mutation := `
+		mutation($issueId: ID!, $blockingIssueId: ID!) {
+			removeBlockedBy(input: {
+				issueId: $issueId,
+				blockingIssueId: $blockingIssueId
+			}) {
+				issue {
+					id
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"issueId":         blockedIssueNodeID,
+		"blockingIssueId": blockingIssueNodeID,
+	}
+
+	var response struct {
+		RemoveBlockedBy struct {
+			Issue struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"removeBlockedBy"`
+	}
+
+	err = c.graphQL.DoWithContext(ctx, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to remove blocked-by relationship: %w", err)
+	}
+
+	return nil
+}
+
 // GetIssueNodeID retrieves the GraphQL node ID for an issue by its number
-func GetIssueNodeID(ctx context.Context, client api.GraphQLClient, owner, repo string, issueNumber int) (string, error) {
+func (c *APIClient) GetIssueNodeID(ctx context.Context, owner, repo string, issueNumber int) (string, error) {
 	query := `
 		query($owner: String!, $repo: String!, $number: Int!) {
 			repository(owner: $owner, name: $repo) {
@@ -91,21 +135,28 @@ func GetIssueNodeID(ctx context.Context, client api.GraphQLClient, owner, repo s
 		} `json:"repository"`
 	}
 
-	err := client.DoWithContext(ctx, query, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
 	if err != nil {
 		return "", fmt.Errorf("failed to query issue #%d: %w", issueNumber, err)
 	}
 
 	if response.Repository.Issue.ID == "" {
-		return "", fmt.Errorf("issue #%d not found in %s/%s", issueNumber, owner, repo)
+		return "", newError(ErrIssueNotFound, "issue #%d not found in %s/%s", issueNumber, owner, repo)
 	}
 
 	return response.Repository.Issue.ID, nil
 }
 
-// ParseIssueReference parses an issue reference in format "owner/repo#number" or just "#number"
+// ParseIssueReference parses an issue reference in format "owner/repo#number", just "#number",
+// or an issue URL on github.com or a GitHub Enterprise Server host
+// (https://<host>/owner/repo/issues/number). URLs must be on host, the context's host;
+// an empty host is the gh CLI default (github.com, or GH_HOST if set).
 // Returns owner, repo, and issue number
-func ParseIssueReference(ref string, defaultOwner, defaultRepo string) (string, string, int, error) {
+func ParseIssueReference(ref string, host, defaultOwner, defaultRepo string) (string, string, int, error) {
+	if strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://") {
+		return parseIssueURL(ref, host)
+	}
+
 	// Handle format: "#123" or "123"
 	if len(ref) > 0 && ref[0] == '#' {
 		num, err := strconv.Atoi(ref[1:])
@@ -147,6 +198,42 @@ func ParseIssueReference(ref string, defaultOwner, defaultRepo string) (string,
 	return owner, repo, num, nil
 }
 
+// parseIssueURL parses an issue URL such as https://github.example.com/owner/repo/issues/123,
+// rejecting URLs on another host than host
+func parseIssueURL(ref, host string) (string, string, int, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid issue URL '%s': %w", ref, err)
+	}
+
+	if host == "" {
+		host, _ = auth.DefaultHost()
+	}
+	if !strings.EqualFold(u.Hostname(), hostname(host)) {
+		return "", "", 0, fmt.Errorf("issue URL '%s' is on %s, but the context's host is %s", ref, u.Hostname(), host)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 4 || parts[2] != "issues" || parts[0] == "" || parts[1] == "" {
+		return "", "", 0, fmt.Errorf("invalid issue URL '%s': expected https://<host>/owner/repo/issues/number", ref)
+	}
+
+	num, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid issue number in '%s': %w", ref, err)
+	}
+
+	return parts[0], parts[1], num, nil
+}
+
+// hostname strips the scheme and port from a configured host
+func hostname(host string) string {
+	if u, err := url.Parse("https://" + strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")); err == nil {
+		return u.Hostname()
+	}
+	return host
+}
+
 // splitOnce splits a string on the first occurrence of sep
 func splitOnce(s string, sep rune) []string {
 	for i, c := range s {
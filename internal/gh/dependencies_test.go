@@ -0,0 +1,54 @@
+package gh
+
+import "testing"
+
+func TestParseIssueReference(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        string
+		host       string // The context's host
+		wantOwner  string
+		wantRepo   string
+		wantNumber int
+		wantErr    string
+	}{
+		{name: "number", ref: "12", wantOwner: "acme", wantRepo: "pm", wantNumber: 12},
+		{name: "hash number", ref: "#12", wantOwner: "acme", wantRepo: "pm", wantNumber: 12},
+		{name: "full reference", ref: "acme/backend#3", wantOwner: "acme", wantRepo: "backend", wantNumber: 3},
+		{name: "URL on the default host", ref: "https://github.com/acme/backend/issues/3", wantOwner: "acme", wantRepo: "backend", wantNumber: 3},
+		{name: "URL on the context's host", ref: "https://GHE.example.com/acme/backend/issues/3/", host: "ghe.example.com", wantOwner: "acme", wantRepo: "backend", wantNumber: 3},
+		{name: "context host with a port", ref: "https://ghe.example.com:8443/acme/pm/issues/1", host: "ghe.example.com:8443", wantOwner: "acme", wantRepo: "pm", wantNumber: 1},
+		{
+			name:    "github.com URL in an enterprise context",
+			ref:     "https://github.com/acme/pm/issues/1",
+			host:    "ghe.example.com",
+			wantErr: "issue URL 'https://github.com/acme/pm/issues/1' is on github.com, but the context's host is ghe.example.com",
+		},
+		{
+			name:    "enterprise URL in a default context",
+			ref:     "https://ghe.example.com/acme/pm/issues/1",
+			wantErr: "issue URL 'https://ghe.example.com/acme/pm/issues/1' is on ghe.example.com, but the context's host is github.com",
+		},
+		{name: "pull request URL", ref: "https://github.com/acme/pm/pull/1", wantErr: "invalid issue URL 'https://github.com/acme/pm/pull/1': expected https://<host>/owner/repo/issues/number"},
+		{name: "not a reference", ref: "acme/pm", wantErr: "invalid issue reference 'acme/pm': expected format 'owner/repo#number' or '#number'"},
+	}
+
+	t.Setenv("GH_HOST", "github.com")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, repo, number, err := ParseIssueReference(tt.ref, tt.host, "acme", "pm")
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if owner != tt.wantOwner || repo != tt.wantRepo || number != tt.wantNumber {
+				t.Errorf("got %s/%s#%d, want %s/%s#%d", owner, repo, number, tt.wantOwner, tt.wantRepo, tt.wantNumber)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+)
+
+// DraftIssue is a draft issue in a project: an item with a title and body
+// that belongs to no repository until it is converted to an issue
+type DraftIssue struct {
+	ID        string // Project item ID
+	Title     string
+	Body      string
+	Assignees []string
+	Fields    map[string]string // Single-select field name -> selected option
+}
+
+// AddDraftIssueToProject adds a draft issue to a project and returns its project item ID
+func (c *APIClient) AddDraftIssueToProject(ctx context.Context, projectID, title, body string, assigneeIDs []string) (string, error) {
+	if title == "" {
+		return "", fmt.Errorf("title cannot be empty")
+	}
+
+	mutation := `
+		mutation($input: AddProjectV2DraftIssueInput!) {
+			addProjectV2DraftIssue(input: $input) {
+				projectItem {
+					id
+				}
+			}
+		}
+	`
+
+	input := map[string]interface{}{
+		"projectId": projectID,
+		"title":     title,
+		"body":      body,
+	}
+	if len(assigneeIDs) > 0 {
+		input["assigneeIds"] = assigneeIDs
+	}
+
+	var response struct {
+		AddProjectV2DraftIssue struct {
+			ProjectItem struct {
+				ID string `json:"id"`
+			} `json:"projectItem"`
+		} `json:"addProjectV2DraftIssue"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, mutation, map[string]interface{}{"input": input}, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to add draft issue to project: %w", err)
+	}
+
+	return response.AddProjectV2DraftIssue.ProjectItem.ID, nil
+}
+
+// ListDraftIssues lists the draft issues of a project with their single-select field values
+func (c *APIClient) ListDraftIssues(ctx context.Context, projectID string) ([]DraftIssue, error) {
+	query := `
+		query($projectId: ID!, $first: Int!, $cursor: String) {
+			` + rateLimitSelection + `
+			node(id: $projectId) {
+				... on ProjectV2 {
+					items(first: $first, after: $cursor) {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+						nodes {
+							id
+							type
+							content {
+								... on DraftIssue {
+									title
+									body
+									assignees(first: 10) {
+										nodes {
+											login
+										}
+									}
+								}
+							}
+							fieldValues(first: 20) {
+								nodes {
+									... on ProjectV2ItemFieldSingleSelectValue {
+										name
+										field {
+											... on ProjectV2SingleSelectField {
+												name
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	type draftNode struct {
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		Content *struct {
+			Title     string `json:"title"`
+			Body      string `json:"body"`
+			Assignees struct {
+				Nodes []struct {
+					Login string `json:"login"`
+				} `json:"nodes"`
+			} `json:"assignees"`
+		} `json:"content"`
+		FieldValues struct {
+			Nodes []struct {
+				Name  string `json:"name"`
+				Field struct {
+					Name string `json:"name"`
+				} `json:"field"`
+			} `json:"nodes"`
+		} `json:"fieldValues"`
+	}
+
+	nodes, err := paginate(func(cursor *string) ([]draftNode, PageInfo, error) {
+		variables := map[string]interface{}{
+			"projectId": projectID,
+			"first":     pageSize,
+			"cursor":    cursor,
+		}
+
+		var response struct {
+			Node struct {
+				Items struct {
+					Nodes    []draftNode `json:"nodes"`
+					PageInfo PageInfo    `json:"pageInfo"`
+				} `json:"items"`
+			} `json:"node"`
+		}
+
+		err := c.graphQL.DoWithContext(ctx, query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query project draft issues: %w", err)
+		}
+
+		return response.Node.Items.Nodes, response.Node.Items.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var drafts []DraftIssue
+	for _, node := range nodes {
+		if node.Type != "DRAFT_ISSUE" || node.Content == nil {
+			continue
+		}
+
+		draft := DraftIssue{
+			ID:     node.ID,
+			Title:  node.Content.Title,
+			Body:   node.Content.Body,
+			Fields: make(map[string]string),
+		}
+		for _, assignee := range node.Content.Assignees.Nodes {
+			draft.Assignees = append(draft.Assignees, assignee.Login)
+		}
+		for _, value := range node.FieldValues.Nodes {
+			if value.Field.Name != "" {
+				draft.Fields[value.Field.Name] = value.Name
+			}
+		}
+		drafts = append(drafts, draft)
+	}
+
+	return drafts, nil
+}
+
+// ConvertDraftIssue converts a draft issue to an issue in a repository. The
+// project item keeps its ID and field values.
+func (c *APIClient) ConvertDraftIssue(ctx context.Context, itemID, repositoryID string) (*Issue, error) {
+	mutation := `
+		mutation($itemId: ID!, $repositoryId: ID!) {
+			convertProjectV2DraftIssueItemToIssue(input: {
+				itemId: $itemId
+				repositoryId: $repositoryId
+			}) {
+				item {
+					content {
+						... on Issue {
+							id
+							number
+							url
+							title
+							body
+						}
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"itemId":       itemID,
+		"repositoryId": repositoryID,
+	}
+
+	var response struct {
+		ConvertProjectV2DraftIssueItemToIssue struct {
+			Item struct {
+				Content Issue `json:"content"`
+			} `json:"item"`
+		} `json:"convertProjectV2DraftIssueItemToIssue"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, mutation, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert draft issue: %w", err)
+	}
+
+	issue := response.ConvertProjectV2DraftIssueItemToIssue.Item.Content
+	if issue.ID == "" {
+		return nil, fmt.Errorf("failed to convert draft issue: item %s is not a draft issue", itemID)
+	}
+	return &issue, nil
+}
+
+// SetIssueType sets the organization issue type of an issue
+func (c *APIClient) SetIssueType(ctx context.Context, issueNodeID, issueTypeID string) error {
+	mutation := `
+		mutation($input: UpdateIssueInput!) {
+			updateIssue(input: $input) {
+				issue {
+					id
+				}
+			}
+		}
+	`
+
+	input := map[string]interface{}{
+		"id":          issueNodeID,
+		"issueTypeId": issueTypeID,
+	}
+
+	var response struct {
+		UpdateIssue struct {
+			Issue struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	err := c.issueTypes.DoWithContext(ctx, mutation, map[string]interface{}{"input": input}, &response)
+	if err != nil {
+		return fmt.Errorf("failed to set issue type: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,374 @@
+// Package dryrun wraps a gh.Client so that mutations are recorded instead of
+// sent. Queries still reach GitHub, so every ID a mutation needs is resolved
+// and lookups fail the way they would in a real run.
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+)
+
+// Mutation is a mutation that a real run would send
+type Mutation struct {
+	Name      string            // GraphQL mutation, or REST method and path
+	Variables map[string]string // Input with resolved IDs; lists are comma-separated
+}
+
+// Client is a gh.Client that records mutations instead of sending them.
+// Issues it pretends to create or transfer get placeholder node IDs and
+// negative numbers, which later calls resolve like real ones.
+type Client struct {
+	gh.Client
+
+	mu        sync.Mutex
+	mutations []Mutation
+	issues    map[string]string // "owner/repo#number" -> node ID of a planned issue
+	items     map[string]string // Issue node ID -> project item ID of planned items
+	created   int
+}
+
+var _ gh.Client = (*Client)(nil)
+var _ gh.Invalidator = (*Client)(nil)
+
+// New wraps client so that its mutations are only recorded
+func New(client gh.Client) *Client {
+	return &Client{
+		Client: client,
+		issues: make(map[string]string),
+		items:  make(map[string]string),
+	}
+}
+
+// Mutations returns the recorded mutations in the order they were called
+func (c *Client) Mutations() []Mutation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Mutation(nil), c.mutations...)
+}
+
+// InvalidateProjectFields passes the invalidation on to a caching client
+func (c *Client) InvalidateProjectFields(projectID string) {
+	if invalidator, ok := c.Client.(gh.Invalidator); ok {
+		invalidator.InvalidateProjectFields(projectID)
+	}
+}
+
+// InvalidateIssueTypes passes the invalidation on to a caching client
+func (c *Client) InvalidateIssueTypes(org string) {
+	if invalidator, ok := c.Client.(gh.Invalidator); ok {
+		invalidator.InvalidateIssueTypes(org)
+	}
+}
+
+// placeholder reports whether id is a node ID made up by the dry run
+func placeholder(id string) bool {
+	return strings.HasPrefix(id, "<") && strings.HasSuffix(id, ">")
+}
+
+func (c *Client) record(name string, variables map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, value := range variables {
+		if value == "" {
+			delete(variables, key)
+		}
+	}
+	c.mutations = append(c.mutations, Mutation{Name: name, Variables: variables})
+}
+
+// plan registers an issue that doesn't exist yet and returns its number
+func (c *Client) plan(owner, repo, nodeID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	number := -(len(c.issues) + 1)
+	c.issues[issueKey(owner, repo, number)] = nodeID
+	return number
+}
+
+func issueKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
+
+// GetIssueNodeID resolves planned issues to their placeholder node ID
+func (c *Client) GetIssueNodeID(ctx context.Context, owner, repo string, issueNumber int) (string, error) {
+	c.mu.Lock()
+	nodeID, ok := c.issues[issueKey(owner, repo, issueNumber)]
+	c.mu.Unlock()
+	if ok {
+		return nodeID, nil
+	}
+	return c.Client.GetIssueNodeID(ctx, owner, repo, issueNumber)
+}
+
+// GetProjectItemID resolves planned project items to their placeholder ID
+func (c *Client) GetProjectItemID(ctx context.Context, projectID, issueNodeID string) (string, error) {
+	c.mu.Lock()
+	itemID, ok := c.items[issueNodeID]
+	c.mu.Unlock()
+	if ok {
+		return itemID, nil
+	}
+	if placeholder(issueNodeID) {
+		return "", nil
+	}
+	return c.Client.GetProjectItemID(ctx, projectID, issueNodeID)
+}
+
+// CreateIssue records createIssue and returns a planned issue
+func (c *Client) CreateIssue(ctx context.Context, owner, repo, title, body string, opts gh.CreateIssueOptions) (*gh.Issue, error) {
+	if title == "" {
+		return nil, fmt.Errorf("title cannot be empty")
+	}
+	repoID, err := c.Client.GetRepositoryNodeID(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query repository id: %w", err)
+	}
+
+	c.mu.Lock()
+	c.created++
+	nodeID := fmt.Sprintf("<new issue %d>", c.created)
+	c.mu.Unlock()
+
+	c.record("createIssue", map[string]string{
+		"repositoryId": repoID,
+		"title":        title,
+		"body":         body,
+		"issueTypeId":  opts.IssueTypeID,
+		"labelIds":     strings.Join(opts.LabelIDs, ", "),
+		"assigneeIds":  strings.Join(opts.AssigneeIDs, ", "),
+		"milestoneId":  opts.MilestoneID,
+	})
+
+	number := c.plan(owner, repo, nodeID)
+	return &gh.Issue{ID: nodeID, Number: number, Title: title, Body: body}, nil
+}
+
+// UpdateIssue records updateIssue
+func (c *Client) UpdateIssue(ctx context.Context, issueNodeID string, title, body *string) error {
+	if title == nil && body == nil {
+		return nil
+	}
+	variables := map[string]string{"id": issueNodeID}
+	if title != nil {
+		variables["title"] = *title
+	}
+	if body != nil {
+		variables["body"] = *body
+	}
+	c.record("updateIssue", variables)
+	return nil
+}
+
+// SetIssueType records updateIssue with an issue type
+func (c *Client) SetIssueType(ctx context.Context, issueNodeID, issueTypeID string) error {
+	c.record("updateIssue", map[string]string{"id": issueNodeID, "issueTypeId": issueTypeID})
+	return nil
+}
+
+// CloseIssue records closeIssue
+func (c *Client) CloseIssue(ctx context.Context, issueNodeID string) error {
+	c.record("closeIssue", map[string]string{"issueId": issueNodeID, "stateReason": "NOT_PLANNED"})
+	return nil
+}
+
+// DeleteIssue records deleteIssue
+func (c *Client) DeleteIssue(ctx context.Context, issueNodeID string) error {
+	c.record("deleteIssue", map[string]string{"issueId": issueNodeID})
+	return nil
+}
+
+// TransferIssue records transferIssue. The issue keeps its node ID and gets
+// a planned number in the target repository.
+func (c *Client) TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo, sourceRepo string) (int, error) {
+	sourceOwner, sourceName, ok := strings.Cut(sourceRepo, "/")
+	if !ok {
+		return 0, fmt.Errorf("invalid source repo format '%s', expected 'owner/repo'", sourceRepo)
+	}
+	issueNodeID, err := c.GetIssueNodeID(ctx, sourceOwner, sourceName, issueNumber)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get issue node ID: %w", err)
+	}
+	repoID, err := c.Client.GetRepositoryNodeID(ctx, targetOwner, targetRepo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get repository node ID: %w", err)
+	}
+
+	c.record("transferIssue", map[string]string{"issueId": issueNodeID, "repositoryId": repoID})
+	return c.plan(targetOwner, targetRepo, issueNodeID), nil
+}
+
+// CreateLabel records the REST call that creates a label
+func (c *Client) CreateLabel(ctx context.Context, owner, repo string, label gh.Label) (*gh.Label, error) {
+	c.record(fmt.Sprintf("POST repos/%s/%s/labels", owner, repo), map[string]string{
+		"name":        label.Name,
+		"color":       strings.TrimPrefix(label.Color, "#"),
+		"description": label.Description,
+	})
+	label.ID = fmt.Sprintf("<new label %s>", label.Name)
+	return &label, nil
+}
+
+// AddLabelsToIssue records addLabelsToLabelable
+func (c *Client) AddLabelsToIssue(ctx context.Context, issueNodeID string, labelIDs []string) error {
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	c.record("addLabelsToLabelable", map[string]string{
+		"labelableId": issueNodeID,
+		"labelIds":    strings.Join(labelIDs, ", "),
+	})
+	return nil
+}
+
+// AddSubIssue records addSubIssue
+func (c *Client) AddSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
+	return c.recordRelation(ctx, "addSubIssue", "subIssueId", owner, repo, parentNumber, childNumber)
+}
+
+// RemoveSubIssue records removeSubIssue
+func (c *Client) RemoveSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
+	return c.recordRelation(ctx, "removeSubIssue", "subIssueId", owner, repo, parentNumber, childNumber)
+}
+
+// AddBlockedBy records addBlockedBy
+func (c *Client) AddBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error {
+	return c.recordRelation(ctx, "addBlockedBy", "blockingIssueId", owner, repo, blockedIssueNumber, blockingIssueNumber)
+}
+
+// RemoveBlockedBy records removeBlockedBy
+func (c *Client) RemoveBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error {
+	return c.recordRelation(ctx, "removeBlockedBy", "blockingIssueId", owner, repo, blockedIssueNumber, blockingIssueNumber)
+}
+
+// recordRelation resolves two issues of a repository and records a mutation between them
+func (c *Client) recordRelation(ctx context.Context, name, otherVariable, owner, repo string, issueNumber, otherNumber int) error {
+	issueID, err := c.GetIssueNodeID(ctx, owner, repo, issueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get issue node ID: %w", err)
+	}
+	otherID, err := c.GetIssueNodeID(ctx, owner, repo, otherNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get issue node ID: %w", err)
+	}
+	c.record(name, map[string]string{"issueId": issueID, otherVariable: otherID})
+	return nil
+}
+
+// CreateIssueType records createIssueType
+func (c *Client) CreateIssueType(ctx context.Context, orgID, name, description string) (*templates.IssueTypeConfig, error) {
+	c.record("createIssueType", map[string]string{"ownerId": orgID, "name": name, "description": description})
+	return &templates.IssueTypeConfig{
+		ID:          fmt.Sprintf("<new issue type %s>", name),
+		Name:        name,
+		Description: description,
+		IsEnabled:   true,
+	}, nil
+}
+
+// CreateSingleSelectField records createProjectV2Field
+func (c *Client) CreateSingleSelectField(ctx context.Context, projectID, fieldName string, options map[string]gh.FieldColor) (*gh.Field, error) {
+	field := &gh.Field{ID: fmt.Sprintf("<new field %s>", fieldName), Name: fieldName}
+	for _, name := range optionNames(options) {
+		field.Options = append(field.Options, gh.FieldOption{
+			ID:    fmt.Sprintf("<new option %s>", name),
+			Name:  name,
+			Color: options[name],
+		})
+	}
+
+	c.record("createProjectV2Field", map[string]string{
+		"projectId":           projectID,
+		"name":                fieldName,
+		"singleSelectOptions": strings.Join(optionNames(options), ", "),
+	})
+	return field, nil
+}
+
+// AddOptionsToField records updateProjectV2Field
+func (c *Client) AddOptionsToField(ctx context.Context, fieldID string, options map[string]gh.FieldColor) error {
+	c.record("updateProjectV2Field", map[string]string{
+		"fieldId":             fieldID,
+		"singleSelectOptions": strings.Join(optionNames(options), ", "),
+	})
+	return nil
+}
+
+// AddIssueToProject records addProjectV2ItemById and returns a planned item ID
+func (c *Client) AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (string, error) {
+	c.record("addProjectV2ItemById", map[string]string{"projectId": projectID, "contentId": issueNodeID})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	itemID := fmt.Sprintf("<new project item %d>", len(c.items)+1)
+	c.items[issueNodeID] = itemID
+	return itemID, nil
+}
+
+// DeleteProjectItem records deleteProjectV2Item
+func (c *Client) DeleteProjectItem(ctx context.Context, projectID, itemID string) error {
+	c.record("deleteProjectV2Item", map[string]string{"projectId": projectID, "itemId": itemID})
+	return nil
+}
+
+// AddDraftIssueToProject records addProjectV2DraftIssue and returns a planned item ID
+func (c *Client) AddDraftIssueToProject(ctx context.Context, projectID, title, body string, assigneeIDs []string) (string, error) {
+	if title == "" {
+		return "", fmt.Errorf("title cannot be empty")
+	}
+	c.record("addProjectV2DraftIssue", map[string]string{
+		"projectId":   projectID,
+		"title":       title,
+		"body":        body,
+		"assigneeIds": strings.Join(assigneeIDs, ", "),
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.created++
+	draftID := fmt.Sprintf("<new draft issue %d>", c.created)
+	itemID := fmt.Sprintf("<new project item %d>", len(c.items)+1)
+	c.items[draftID] = itemID
+	return itemID, nil
+}
+
+// ConvertDraftIssue records convertProjectV2DraftIssueItemToIssue and returns
+// a planned issue
+func (c *Client) ConvertDraftIssue(ctx context.Context, itemID, repositoryID string) (*gh.Issue, error) {
+	c.record("convertProjectV2DraftIssueItemToIssue", map[string]string{"itemId": itemID, "repositoryId": repositoryID})
+
+	c.mu.Lock()
+	c.created++
+	nodeID := fmt.Sprintf("<new issue %d>", c.created)
+	c.items[nodeID] = itemID
+	c.mu.Unlock()
+	return &gh.Issue{ID: nodeID}, nil
+}
+
+// UpdateProjectItemField records updateProjectV2ItemFieldValue
+func (c *Client) UpdateProjectItemField(ctx context.Context, projectID, itemID, fieldID, optionID string) error {
+	c.record("updateProjectV2ItemFieldValue", map[string]string{
+		"projectId":            projectID,
+		"itemId":               itemID,
+		"fieldId":              fieldID,
+		"singleSelectOptionId": optionID,
+	})
+	return nil
+}
+
+func optionNames(options map[string]gh.FieldColor) []string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
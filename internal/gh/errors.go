@@ -0,0 +1,234 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Error kinds. Use errors.Is to check which kind an error returned by a Client is.
+var (
+	ErrMissingScope          = errors.New("token is missing a required scope")
+	ErrPermissionDenied      = errors.New("permission denied")
+	ErrIssueNotFound         = errors.New("issue not found")
+	ErrRepoNotFound          = errors.New("repository not found")
+	ErrProjectNotFound       = errors.New("project not found")
+	ErrIssueTypesUnavailable = errors.New("issue types are not available")
+)
+
+// Error is a GitHub API error classified into one of the Err* kinds
+type Error struct {
+	Kind   error    // One of the Err* kinds
+	Scopes []string // Scopes the request needs (ErrMissingScope only)
+	Err    error    // The underlying error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap exposes both the kind and the underlying error to errors.Is and errors.As
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Hint returns an actionable suggestion for resolving the error
+func (e *Error) Hint() string {
+	switch e.Kind {
+	case ErrMissingScope:
+		scopes := e.Scopes
+		if len(scopes) == 0 {
+			scopes = []string{"project"}
+		}
+		return fmt.Sprintf("Run: gh auth refresh -s %s", strings.Join(scopes, ","))
+	case ErrPermissionDenied:
+		return "Check that your account has write access to the repository and project (gh auth status shows the active account)"
+	case ErrIssueNotFound:
+		return "Check the issue number and repository. Transferred issues get a new number in the target repository"
+	case ErrRepoNotFound:
+		return "Check the repository names in your context (gh project-management context current) and that your account can access them"
+	case ErrProjectNotFound:
+		return "Check the project number with 'gh project list --owner <owner>' and update it with 'gh project-management context update'"
+	case ErrIssueTypesUnavailable:
+		return "Issue types must be enabled in the organization settings; issues are created without a type until then"
+	}
+	return ""
+}
+
+// Hint returns the remediation hint of a classified error, or "" if err has none
+func Hint(err error) string {
+	var ghErr *Error
+	if errors.As(err, &ghErr) {
+		return ghErr.Hint()
+	}
+	return ""
+}
+
+// newError creates a classified error with a formatted message
+func newError(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// scopesPattern extracts the scope list from INSUFFICIENT_SCOPES messages such as
+// "... requires one of the following scopes: ['read:project'], but your token ..."
+var scopesPattern = regexp.MustCompile(`following scopes: \[([^\]]*)\]`)
+
+// classifyError converts go-gh GraphQL and HTTP errors into an *Error when the
+// cause is recognized, and returns err unchanged otherwise.
+// issueTypes is set for requests to the issue types API.
+func classifyError(err error, issueTypes bool) error {
+	if err == nil {
+		return nil
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, item := range gqlErr.Errors {
+			if kind, scopes := classifyGraphQLError(item, issueTypes); kind != nil {
+				return &Error{Kind: kind, Scopes: scopes, Err: err}
+			}
+		}
+		return err
+	}
+
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		if kind, scopes := classifyHTTPError(httpErr); kind != nil {
+			return &Error{Kind: kind, Scopes: scopes, Err: err}
+		}
+	}
+	return err
+}
+
+func classifyGraphQLError(item api.GraphQLErrorItem, issueTypes bool) (error, []string) {
+	message := strings.ToLower(item.Message)
+
+	if item.Type == "INSUFFICIENT_SCOPES" {
+		var scopes []string
+		if match := scopesPattern.FindStringSubmatch(item.Message); match != nil {
+			for _, scope := range strings.Split(match[1], ",") {
+				scope = strings.Trim(strings.TrimSpace(scope), `'"`)
+				if scope != "" {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+		return ErrMissingScope, scopes
+	}
+
+	// The issue types API reports a disabled feature as an unknown field. A
+	// forbidden request is a missing permission, reported as such below.
+	if issueTypes || strings.Contains(message, "issuetype") || strings.Contains(message, "issue type") {
+		if item.Type == "undefinedField" || strings.Contains(message, "doesn't exist on type") {
+			return ErrIssueTypesUnavailable, nil
+		}
+	}
+
+	switch item.Type {
+	case "FORBIDDEN":
+		return ErrPermissionDenied, nil
+	case "NOT_FOUND":
+		switch {
+		case strings.Contains(message, "could not resolve to a repository"):
+			return ErrRepoNotFound, nil
+		case strings.Contains(message, "could not resolve to an issue"):
+			return ErrIssueNotFound, nil
+		case strings.Contains(message, "projectv2"):
+			return ErrProjectNotFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func classifyHTTPError(httpErr *api.HTTPError) (error, []string) {
+	switch httpErr.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrPermissionDenied, nil
+	case http.StatusForbidden:
+		// Rate limits are retried by the transport; don't report them as permission errors
+		if httpErr.Headers.Get("X-RateLimit-Remaining") == "0" {
+			return nil, nil
+		}
+		if scopes := missingScopes(httpErr.Headers); len(scopes) > 0 {
+			return ErrMissingScope, scopes
+		}
+		return ErrPermissionDenied, nil
+	case http.StatusNotFound:
+		if httpErr.RequestURL == nil {
+			return nil, nil
+		}
+		path := httpErr.RequestURL.Path
+		switch {
+		case strings.Contains(path, "/issues/"):
+			return ErrIssueNotFound, nil
+		case strings.Contains(path, "/repos/") && !strings.Contains(path, "/contents/"):
+			return ErrRepoNotFound, nil
+		}
+	}
+	return nil, nil
+}
+
+// missingScopes returns the scopes the endpoint accepts when the token has none of them
+func missingScopes(header http.Header) []string {
+	accepted := splitScopes(header.Get("X-Accepted-OAuth-Scopes"))
+	if len(accepted) == 0 {
+		return nil
+	}
+	granted := splitScopes(header.Get("X-OAuth-Scopes"))
+	for _, scope := range accepted {
+		for _, g := range granted {
+			if g == scope {
+				return nil
+			}
+		}
+	}
+	return accepted
+}
+
+func splitScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// graphQLClient wraps a go-gh GraphQL client so every error is classified
+type graphQLClient struct {
+	client     *api.GraphQLClient
+	issueTypes bool
+}
+
+// DoWithContext runs a GraphQL query or mutation
+func (c *graphQLClient) DoWithContext(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	return classifyError(c.client.DoWithContext(ctx, query, variables, response), c.issueTypes)
+}
+
+// restClient wraps a go-gh REST client so every error is classified
+type restClient struct {
+	client *api.RESTClient
+}
+
+// Get sends a GET request to a REST API path
+func (c *restClient) Get(path string, response interface{}) error {
+	return classifyError(c.client.Get(path, response), false)
+}
+
+// Post sends a POST request with a JSON body to a REST API path
+func (c *restClient) Post(path string, body io.Reader, response interface{}) error {
+	return classifyError(c.client.Post(path, body, response), false)
+}
+
+// RequestWithContext sends a request and returns the raw response, for callers that need headers
+func (c *restClient) RequestWithContext(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	resp, err := c.client.RequestWithContext(ctx, method, path, body)
+	return resp, classifyError(err, false)
+}
@@ -0,0 +1,1349 @@
+// Package fake provides an in-memory implementation of gh.Client so that
+// commands and pkg/ operations can be exercised without a GitHub account.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+)
+
+// Client is an in-memory GitHub backend implementing gh.Client
+type Client struct {
+	mu sync.Mutex
+
+	// Login is returned by GetCurrentUser and owns user projects and repositories
+	Login string
+	// Scopes is returned by GetTokenScopes; nil simulates a fine-grained token
+	Scopes []string
+
+	nextID        int
+	organizations map[string]*organization
+	repos         map[string]*Repo
+	users         map[string]string // Login -> node ID
+	issues        map[string]*Issue
+	drafts        map[string]*Draft
+	projects      map[string]*Project
+	templates     map[string]*templates.IssueTemplate
+}
+
+var _ gh.Client = (*Client)(nil)
+
+type organization struct {
+	gh.Organization
+	ID                 string
+	IssueTypes         []templates.IssueTypeConfig
+	issueTypesDisabled bool
+}
+
+// Repo is a repository stored in the fake backend
+type Repo struct {
+	ID          string
+	Owner       string
+	Name        string
+	Description string
+	Permission  string // Viewer permission, ADMIN by default
+	Labels      []gh.Label
+	Milestones  []gh.Milestone
+	nextNumber  int
+}
+
+// Issue is an issue stored in the fake backend
+type Issue struct {
+	gh.Issue
+	Owner       string
+	Repo        string
+	IssueTypeID string
+	State       string   // OPEN or CLOSED
+	Assignees   []string // Logins
+	Labels      []string // Label names
+	Milestone   string   // Milestone title
+	Parent      string   // Node ID of the parent issue
+	SubIssues   []string // Node IDs of sub-issues
+	BlockedBy   []string // Node IDs of blocking issues
+}
+
+// Draft is the content of a draft issue item stored in the fake backend
+type Draft struct {
+	ID        string
+	Title     string
+	Body      string
+	Assignees []string // Logins
+}
+
+// Project is a Project V2 stored in the fake backend
+type Project struct {
+	gh.Project
+	Fields []gh.Field
+	Items  []*Item
+}
+
+// Item is a project item; FieldValues maps field IDs to option IDs
+type Item struct {
+	ID          string
+	ContentID   string // Node ID of an issue or a draft
+	FieldValues map[string]string
+}
+
+// New creates an empty fake backend for the given viewer login
+func New(login string) *Client {
+	return &Client{
+		Login:         login,
+		Scopes:        []string{"project", "read:org", "repo"},
+		organizations: make(map[string]*organization),
+		repos:         make(map[string]*Repo),
+		users:         make(map[string]string),
+		issues:        make(map[string]*Issue),
+		drafts:        make(map[string]*Draft),
+		projects:      make(map[string]*Project),
+		templates:     make(map[string]*templates.IssueTemplate),
+	}
+}
+
+func (c *Client) newID(prefix string) string {
+	c.nextID++
+	return fmt.Sprintf("%s_%d", prefix, c.nextID)
+}
+
+// apiError returns an error classified like the ones APIClient returns
+func apiError(kind error, format string, args ...interface{}) error {
+	return &gh.Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+func repoKey(owner, name string) string {
+	return owner + "/" + name
+}
+
+// AddOrganization registers an organization the viewer belongs to
+func (c *Client) AddOrganization(login, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.organizations[login] = &organization{
+		Organization: gh.Organization{Login: login, Name: name},
+		ID:           c.newID("O"),
+	}
+}
+
+// DisableIssueTypes makes the issue types API of an organization unavailable
+func (c *Client) DisableIssueTypes(org string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if o, ok := c.organizations[org]; ok {
+		o.issueTypesDisabled = true
+	}
+}
+
+// AddIssueType adds an issue type to a registered organization
+func (c *Client) AddIssueType(org, name string, enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if o, ok := c.organizations[org]; ok {
+		o.IssueTypes = append(o.IssueTypes, templates.IssueTypeConfig{ID: c.newID("IT"), Name: name, IsEnabled: enabled})
+	}
+}
+
+// AddRepo registers a repository and returns it
+func (c *Client) AddRepo(owner, name string) *Repo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	repo := &Repo{ID: c.newID("R"), Owner: owner, Name: name, Permission: "ADMIN", nextNumber: 1}
+	c.repos[repoKey(owner, name)] = repo
+	return repo
+}
+
+// AddLabel creates a label in a registered repository
+func (c *Client) AddLabel(owner, repo, name, color string) gh.Label {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	label := gh.Label{ID: c.newID("LA"), Name: name, Color: color}
+	if r, ok := c.repos[repoKey(owner, repo)]; ok {
+		r.Labels = append(r.Labels, label)
+	}
+	return label
+}
+
+// AddMilestone creates an open milestone in a registered repository
+func (c *Client) AddMilestone(owner, repo, title string) gh.Milestone {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	milestone := gh.Milestone{ID: c.newID("MI"), Title: title}
+	if r, ok := c.repos[repoKey(owner, repo)]; ok {
+		milestone.Number = len(r.Milestones) + 1
+		r.Milestones = append(r.Milestones, milestone)
+	}
+	return milestone
+}
+
+// AddUser registers a user that can be assigned to issues. The viewer is always known.
+func (c *Client) AddUser(login string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.users[login] = c.newID("U")
+}
+
+// AddProject registers a project owned by an organization, or by the viewer if owner is empty
+func (c *Client) AddProject(owner string, number int, title string) *Project {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project := &Project{
+		Project: gh.Project{ID: c.newID("PVT"), Number: number, Title: title, Owner: owner},
+	}
+	if owner == "" {
+		project.Owner = "user"
+	}
+	c.projects[project.ID] = project
+	return project
+}
+
+// SetTemplate stores an issue form template in a repository's .github/ISSUE_TEMPLATE
+func (c *Client) SetTemplate(owner, repo, issueType string, template *templates.IssueTemplate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.templates[repoKey(owner, repo)+"/"+templates.GetTemplateFileName(issueType)] = template
+}
+
+// Issue returns a stored issue by repository and number
+func (c *Client) Issue(owner, repo string, number int) (*Issue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue := c.findIssue(owner, repo, number)
+	return issue, issue != nil
+}
+
+// IssueByID returns a stored issue by node ID
+func (c *Client) IssueByID(id string) (*Issue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, ok := c.issues[id]
+	return issue, ok
+}
+
+// FieldValue returns the option name selected for a field on an issue's project item
+func (c *Client) FieldValue(projectID, issueNodeID, fieldName string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return "", false
+	}
+	field := gh.FindFieldByName(project.Fields, fieldName)
+	if field == nil {
+		return "", false
+	}
+	for _, item := range project.Items {
+		if item.ContentID != issueNodeID {
+			continue
+		}
+		optionID, ok := item.FieldValues[field.ID]
+		if !ok {
+			return "", false
+		}
+		for _, opt := range field.Options {
+			if opt.ID == optionID {
+				return opt.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (c *Client) findIssue(owner, repo string, number int) *Issue {
+	for _, issue := range c.issues {
+		if issue.Owner == owner && issue.Repo == repo && issue.Number == number {
+			return issue
+		}
+	}
+	return nil
+}
+
+func (c *Client) findProject(owner string, number int) *Project {
+	for _, project := range c.projects {
+		if project.Owner == owner && project.Number == number {
+			return project
+		}
+	}
+	return nil
+}
+
+func (c *Client) findField(fieldID string) *gh.Field {
+	for _, project := range c.projects {
+		for i := range project.Fields {
+			if project.Fields[i].ID == fieldID {
+				return &project.Fields[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) buildOptions(options map[string]gh.FieldColor) []gh.FieldOption {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]gh.FieldOption, 0, len(names))
+	for _, name := range names {
+		result = append(result, gh.FieldOption{ID: c.newID("opt"), Name: name, Color: options[name]})
+	}
+	return result
+}
+
+func issueURL(owner, repo string, number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%d", owner, repo, number)
+}
+
+func appendUnique(ids []string, id string) []string {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+func remove(ids []string, id string) []string {
+	result := ids[:0]
+	for _, existing := range ids {
+		if existing != id {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+// GetCurrentUser returns the configured viewer login
+func (c *Client) GetCurrentUser() (string, error) {
+	return c.Login, nil
+}
+
+// GetUserNodeID returns the node ID of the viewer or a registered user
+func (c *Client) GetUserNodeID(ctx context.Context, login string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if login == c.Login {
+		return "U_" + login, nil
+	}
+	if id, ok := c.users[login]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("user '%s' not found", login)
+}
+
+func (c *Client) userLogin(id string) string {
+	if id == "U_"+c.Login {
+		return c.Login
+	}
+	for login, userID := range c.users {
+		if userID == id {
+			return login
+		}
+	}
+	return ""
+}
+
+// GetTokenScopes returns Scopes
+func (c *Client) GetTokenScopes(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.Scopes...), nil
+}
+
+// ListOrganizations lists the registered organizations sorted by login
+func (c *Client) ListOrganizations() ([]gh.Organization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	orgs := make([]gh.Organization, 0, len(c.organizations))
+	for _, org := range c.organizations {
+		orgs = append(orgs, org.Organization)
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Login < orgs[j].Login })
+	return orgs, nil
+}
+
+// GetOrgNodeID returns the node ID of a registered organization
+func (c *Client) GetOrgNodeID(ctx context.Context, org string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, ok := c.organizations[org]
+	if !ok {
+		return "", fmt.Errorf("failed to get organization node ID: organization %s not found", org)
+	}
+	return o.ID, nil
+}
+
+func (c *Client) listRepos(owner string) []gh.Repository {
+	repos := make([]gh.Repository, 0)
+	for _, repo := range c.repos {
+		if repo.Owner == owner {
+			repos = append(repos, gh.Repository{Name: repo.Name, Description: repo.Description})
+		}
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+	return repos
+}
+
+// ListOrgRepositories lists repositories owned by an organization
+func (c *Client) ListOrgRepositories(org string) ([]gh.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.listRepos(org), nil
+}
+
+// ListUserRepositories lists repositories owned by the viewer
+func (c *Client) ListUserRepositories() ([]gh.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.listRepos(c.Login), nil
+}
+
+// GetRepositoryPermission returns the viewer permission of a registered repository
+func (c *Client) GetRepositoryPermission(ctx context.Context, owner, repo string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.repos[repoKey(owner, repo)]
+	if !ok {
+		return "", apiError(gh.ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+	}
+	return r.Permission, nil
+}
+
+// GetRepositoryNodeID returns the node ID of a registered repository
+func (c *Client) GetRepositoryNodeID(ctx context.Context, owner, repo string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.repos[repoKey(owner, repo)]
+	if !ok {
+		return "", apiError(gh.ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+	}
+	return r.ID, nil
+}
+
+// GetTemplateFromRepo returns a template stored with SetTemplate, or nil if there is none
+func (c *Client) GetTemplateFromRepo(ctx context.Context, owner, repo, issueType string) (*templates.IssueTemplate, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	template, ok := c.templates[repoKey(owner, repo)+"/"+templates.GetTemplateFileName(issueType)]
+	if !ok {
+		return nil, "", nil
+	}
+	return template, template.LastUpdated, nil
+}
+
+// CreateIssue creates an issue with the next number in the repository
+func (c *Client) CreateIssue(ctx context.Context, owner, repo, title, body string, opts gh.CreateIssueOptions) (*gh.Issue, error) {
+	if owner == "" || repo == "" {
+		return nil, fmt.Errorf("owner and repo cannot be empty")
+	}
+	if title == "" {
+		return nil, fmt.Errorf("title cannot be empty")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.repos[repoKey(owner, repo)]
+	if !ok {
+		return nil, apiError(gh.ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+	}
+
+	issue := &Issue{
+		Issue: gh.Issue{
+			ID:     c.newID("I"),
+			Number: r.nextNumber,
+			URL:    issueURL(owner, repo, r.nextNumber),
+			Title:  title,
+			Body:   body,
+		},
+		Owner:       owner,
+		Repo:        repo,
+		IssueTypeID: opts.IssueTypeID,
+		State:       "OPEN",
+	}
+	for _, id := range opts.LabelIDs {
+		label := findLabelByID(r.Labels, id)
+		if label == nil {
+			return nil, fmt.Errorf("failed to create issue: label %s not found in %s/%s", id, owner, repo)
+		}
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	for _, id := range opts.AssigneeIDs {
+		login := c.userLogin(id)
+		if login == "" {
+			return nil, fmt.Errorf("failed to create issue: user %s not found", id)
+		}
+		issue.Assignees = append(issue.Assignees, login)
+	}
+	if opts.MilestoneID != "" {
+		for _, m := range r.Milestones {
+			if m.ID == opts.MilestoneID {
+				issue.Milestone = m.Title
+			}
+		}
+		if issue.Milestone == "" {
+			return nil, fmt.Errorf("failed to create issue: milestone %s not found in %s/%s", opts.MilestoneID, owner, repo)
+		}
+	}
+	r.nextNumber++
+	c.issues[issue.ID] = issue
+
+	result := issue.Issue
+	return &result, nil
+}
+
+// UpdateIssue changes the title and/or body of an issue
+func (c *Client) UpdateIssue(ctx context.Context, issueNodeID string, title, body *string) error {
+	if title != nil && *title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, ok := c.issues[issueNodeID]
+	if !ok {
+		return apiError(gh.ErrIssueNotFound, "failed to update issue: issue %s not found", issueNodeID)
+	}
+	if title != nil {
+		issue.Title = *title
+	}
+	if body != nil {
+		issue.Body = *body
+	}
+	return nil
+}
+
+// CloseIssue closes an issue
+func (c *Client) CloseIssue(ctx context.Context, issueNodeID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, ok := c.issues[issueNodeID]
+	if !ok {
+		return apiError(gh.ErrIssueNotFound, "failed to close issue: issue %s not found", issueNodeID)
+	}
+	issue.State = "CLOSED"
+	return nil
+}
+
+// DeleteIssue deletes an issue with its relationships and project items.
+// Like GitHub, it requires ADMIN permission on the repository.
+func (c *Client) DeleteIssue(ctx context.Context, issueNodeID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, ok := c.issues[issueNodeID]
+	if !ok {
+		return apiError(gh.ErrIssueNotFound, "failed to delete issue: issue %s not found", issueNodeID)
+	}
+	if r, ok := c.repos[repoKey(issue.Owner, issue.Repo)]; ok && r.Permission != "ADMIN" {
+		return apiError(gh.ErrPermissionDenied, "failed to delete issue: admin permission on %s/%s required", issue.Owner, issue.Repo)
+	}
+
+	delete(c.issues, issueNodeID)
+	for _, other := range c.issues {
+		if other.Parent == issueNodeID {
+			other.Parent = ""
+		}
+		other.SubIssues = remove(other.SubIssues, issueNodeID)
+		other.BlockedBy = remove(other.BlockedBy, issueNodeID)
+	}
+	for _, project := range c.projects {
+		items := project.Items[:0]
+		for _, item := range project.Items {
+			if item.ContentID != issueNodeID {
+				items = append(items, item)
+			}
+		}
+		project.Items = items
+	}
+	return nil
+}
+
+// GetIssueNodeID returns the node ID of an issue by its number
+func (c *Client) GetIssueNodeID(ctx context.Context, owner, repo string, issueNumber int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue := c.findIssue(owner, repo, issueNumber)
+	if issue == nil {
+		return "", apiError(gh.ErrIssueNotFound, "issue #%d not found in %s/%s", issueNumber, owner, repo)
+	}
+	return issue.ID, nil
+}
+
+// GetIssueDetails returns an issue with its relationships and project field values
+func (c *Client) GetIssueDetails(ctx context.Context, owner, repo string, issueNumber int) (*gh.IssueDetails, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.repos[repoKey(owner, repo)]; !ok {
+		return nil, apiError(gh.ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+	}
+	issue := c.findIssue(owner, repo, issueNumber)
+	if issue == nil {
+		return nil, apiError(gh.ErrIssueNotFound, "issue #%d not found in %s/%s", issueNumber, owner, repo)
+	}
+
+	details := &gh.IssueDetails{
+		Issue:     issue.Issue,
+		Owner:     issue.Owner,
+		Repo:      issue.Repo,
+		State:     issue.State,
+		IssueType: c.issueTypeName(issue.IssueTypeID),
+		Assignees: append([]string(nil), issue.Assignees...),
+		Labels:    append([]string(nil), issue.Labels...),
+		Milestone: issue.Milestone,
+		SubIssues: c.summaries(issue.SubIssues),
+		BlockedBy: c.summaries(issue.BlockedBy),
+		Blocking:  make([]gh.IssueSummary, 0),
+	}
+	if parent, ok := c.issues[issue.Parent]; ok {
+		summary := parent.summary()
+		details.Parent = &summary
+	}
+	for _, other := range c.issues {
+		for _, id := range other.BlockedBy {
+			if id == issue.ID {
+				details.Blocking = append(details.Blocking, other.summary())
+			}
+		}
+	}
+	sort.Slice(details.Blocking, func(i, j int) bool { return details.Blocking[i].Number < details.Blocking[j].Number })
+
+	for _, project := range c.projects {
+		for _, item := range project.Items {
+			if item.ContentID != issue.ID {
+				continue
+			}
+			values := gh.ProjectFieldValues{
+				ProjectID:     project.ID,
+				ProjectNumber: project.Number,
+				ProjectTitle:  project.Title,
+				ItemID:        item.ID,
+				Fields:        c.fieldValues(project, item),
+			}
+			details.Projects = append(details.Projects, values)
+		}
+	}
+	return details, nil
+}
+
+func (i *Issue) summary() gh.IssueSummary {
+	return gh.IssueSummary{Owner: i.Owner, Repo: i.Repo, Number: i.Number, Title: i.Title, State: i.State}
+}
+
+func (c *Client) summaries(ids []string) []gh.IssueSummary {
+	result := make([]gh.IssueSummary, 0, len(ids))
+	for _, id := range ids {
+		if issue, ok := c.issues[id]; ok {
+			result = append(result, issue.summary())
+		}
+	}
+	return result
+}
+
+// fieldValues maps the field names of a project item to their selected option names
+func (c *Client) fieldValues(project *Project, item *Item) map[string]string {
+	values := make(map[string]string)
+	for _, field := range project.Fields {
+		optionID, ok := item.FieldValues[field.ID]
+		if !ok {
+			continue
+		}
+		for _, opt := range field.Options {
+			if opt.ID == optionID {
+				values[field.Name] = opt.Name
+			}
+		}
+	}
+	return values
+}
+
+// ListRecentIssues lists issues in a repository, newest first
+func (c *Client) ListRecentIssues(ctx context.Context, owner, repo string, limit int) ([]gh.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issues := make([]gh.Issue, 0)
+	for _, issue := range c.issues {
+		if issue.Owner == owner && issue.Repo == repo {
+			issues = append(issues, issue.Issue)
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number > issues[j].Number })
+	if limit > 0 && len(issues) > limit {
+		issues = issues[:limit]
+	}
+	return issues, nil
+}
+
+// TransferIssue moves an issue to another repository, keeping its node ID and relationships
+func (c *Client) TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo, sourceRepo string) (int, error) {
+	parts := strings.Split(sourceRepo, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid source repo format '%s', expected 'owner/repo'", sourceRepo)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue := c.findIssue(parts[0], parts[1], issueNumber)
+	if issue == nil {
+		return 0, apiError(gh.ErrIssueNotFound, "failed to get issue node ID: issue #%d not found in %s", issueNumber, sourceRepo)
+	}
+
+	target, ok := c.repos[repoKey(targetOwner, targetRepo)]
+	if !ok {
+		return 0, apiError(gh.ErrRepoNotFound, "failed to get repository node ID: repository %s/%s not found", targetOwner, targetRepo)
+	}
+
+	// Like GitHub, keep only labels the target has and drop the milestone
+	var labels []string
+	for _, name := range issue.Labels {
+		if findLabelByName(target.Labels, name) != nil {
+			labels = append(labels, name)
+		}
+	}
+	issue.Labels = labels
+	issue.Milestone = ""
+
+	issue.Owner = targetOwner
+	issue.Repo = targetRepo
+	issue.Number = target.nextNumber
+	issue.URL = issueURL(targetOwner, targetRepo, issue.Number)
+	target.nextNumber++
+
+	return issue.Number, nil
+}
+
+// ListLabels lists the labels of a repository
+func (c *Client) ListLabels(ctx context.Context, owner, repo string) ([]gh.Label, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.repos[repoKey(owner, repo)]
+	if !ok {
+		return nil, apiError(gh.ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+	}
+	return append([]gh.Label(nil), r.Labels...), nil
+}
+
+// CreateLabel creates a label, failing if the repository already has one with that name
+func (c *Client) CreateLabel(ctx context.Context, owner, repo string, label gh.Label) (*gh.Label, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.repos[repoKey(owner, repo)]
+	if !ok {
+		return nil, apiError(gh.ErrRepoNotFound, "failed to create label '%s': repository %s/%s not found", label.Name, owner, repo)
+	}
+	if findLabelByName(r.Labels, label.Name) != nil {
+		return nil, fmt.Errorf("failed to create label '%s': already exists", label.Name)
+	}
+
+	label.ID = c.newID("LA")
+	r.Labels = append(r.Labels, label)
+	return &label, nil
+}
+
+// AddLabelsToIssue adds labels of the issue's repository to an issue
+func (c *Client) AddLabelsToIssue(ctx context.Context, issueNodeID string, labelIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, ok := c.issues[issueNodeID]
+	if !ok {
+		return apiError(gh.ErrIssueNotFound, "failed to add labels: issue %s not found", issueNodeID)
+	}
+	r := c.repos[repoKey(issue.Owner, issue.Repo)]
+	for _, id := range labelIDs {
+		label := findLabelByID(r.Labels, id)
+		if label == nil {
+			return fmt.Errorf("failed to add labels: label %s not found in %s/%s", id, issue.Owner, issue.Repo)
+		}
+		if !containsFold(issue.Labels, label.Name) {
+			issue.Labels = append(issue.Labels, label.Name)
+		}
+	}
+	return nil
+}
+
+// ListMilestones lists the open milestones of a repository
+func (c *Client) ListMilestones(ctx context.Context, owner, repo string) ([]gh.Milestone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.repos[repoKey(owner, repo)]
+	if !ok {
+		return nil, apiError(gh.ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+	}
+	return append([]gh.Milestone(nil), r.Milestones...), nil
+}
+
+func findLabelByID(labels []gh.Label, id string) *gh.Label {
+	for i := range labels {
+		if labels[i].ID == id {
+			return &labels[i]
+		}
+	}
+	return nil
+}
+
+func findLabelByName(labels []gh.Label, name string) *gh.Label {
+	for i := range labels {
+		if strings.EqualFold(labels[i].Name, name) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) issuePair(owner, repo string, first, second int) (*Issue, *Issue, error) {
+	a := c.findIssue(owner, repo, first)
+	if a == nil {
+		return nil, nil, apiError(gh.ErrIssueNotFound, "issue #%d not found in %s/%s", first, owner, repo)
+	}
+	b := c.findIssue(owner, repo, second)
+	if b == nil {
+		return nil, nil, apiError(gh.ErrIssueNotFound, "issue #%d not found in %s/%s", second, owner, repo)
+	}
+	return a, b, nil
+}
+
+// AddSubIssue links a child issue to a parent issue
+func (c *Client) AddSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	parent, child, err := c.issuePair(owner, repo, parentNumber, childNumber)
+	if err != nil {
+		return err
+	}
+	if child.Parent != "" && child.Parent != parent.ID {
+		return fmt.Errorf("failed to add sub-issue relationship: issue #%d already has a parent", childNumber)
+	}
+
+	child.Parent = parent.ID
+	parent.SubIssues = appendUnique(parent.SubIssues, child.ID)
+	return nil
+}
+
+// RemoveSubIssue unlinks a child issue from its parent
+func (c *Client) RemoveSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	parent, child, err := c.issuePair(owner, repo, parentNumber, childNumber)
+	if err != nil {
+		return err
+	}
+	if child.Parent != parent.ID {
+		return fmt.Errorf("failed to remove sub-issue relationship: issue #%d is not a sub-issue of #%d", childNumber, parentNumber)
+	}
+
+	child.Parent = ""
+	parent.SubIssues = remove(parent.SubIssues, child.ID)
+	return nil
+}
+
+// AddBlockedBy records that one issue is blocked by another
+func (c *Client) AddBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	blocked, blocking, err := c.issuePair(owner, repo, blockedIssueNumber, blockingIssueNumber)
+	if err != nil {
+		return err
+	}
+
+	blocked.BlockedBy = appendUnique(blocked.BlockedBy, blocking.ID)
+	return nil
+}
+
+// RemoveBlockedBy removes the record that one issue is blocked by another
+func (c *Client) RemoveBlockedBy(ctx context.Context, owner, repo string, blockedIssueNumber, blockingIssueNumber int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	blocked, blocking, err := c.issuePair(owner, repo, blockedIssueNumber, blockingIssueNumber)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(blocked.BlockedBy, blocking.ID) {
+		return fmt.Errorf("failed to remove blocked-by relationship: issue #%d is not blocked by #%d", blockedIssueNumber, blockingIssueNumber)
+	}
+
+	blocked.BlockedBy = remove(blocked.BlockedBy, blocking.ID)
+	return nil
+}
+
+// ListOrgIssueTypes lists the issue types of a registered organization
+func (c *Client) ListOrgIssueTypes(ctx context.Context, org string) ([]templates.IssueTypeConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, ok := c.organizations[org]
+	if !ok {
+		return nil, fmt.Errorf("failed to list issue types: organization %s not found", org)
+	}
+	if o.issueTypesDisabled {
+		return nil, apiError(gh.ErrIssueTypesUnavailable, "failed to list issue types: issue types are not enabled for %s", org)
+	}
+	return append([]templates.IssueTypeConfig(nil), o.IssueTypes...), nil
+}
+
+// CreateIssueType adds an enabled issue type to an organization
+func (c *Client) CreateIssueType(ctx context.Context, orgID, name, description string) (*templates.IssueTypeConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, o := range c.organizations {
+		if o.ID != orgID {
+			continue
+		}
+		if o.issueTypesDisabled {
+			return nil, apiError(gh.ErrIssueTypesUnavailable, "failed to create issue type: issue types are not enabled for %s", o.Login)
+		}
+		issueType := templates.IssueTypeConfig{
+			ID:          c.newID("IT"),
+			Name:        name,
+			Description: description,
+			IsEnabled:   true,
+		}
+		o.IssueTypes = append(o.IssueTypes, issueType)
+		return &issueType, nil
+	}
+	return nil, fmt.Errorf("failed to create issue type: organization %s not found", orgID)
+}
+
+func (c *Client) listProjects(owner string) []gh.Project {
+	projects := make([]gh.Project, 0)
+	for _, project := range c.projects {
+		if project.Owner == owner {
+			projects = append(projects, project.Project)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Number < projects[j].Number })
+	return projects
+}
+
+// ListOrgProjects lists projects owned by an organization
+func (c *Client) ListOrgProjects(org string) ([]gh.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.listProjects(org), nil
+}
+
+// ListUserProjects lists projects owned by the viewer
+func (c *Client) ListUserProjects() ([]gh.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.listProjects("user"), nil
+}
+
+// GetProjectNodeID returns the node ID of an organization project, or "" if missing
+func (c *Client) GetProjectNodeID(ctx context.Context, org string, projectNumber int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if project := c.findProject(org, projectNumber); project != nil {
+		return project.ID, nil
+	}
+	return "", apiError(gh.ErrProjectNotFound, "project %d not found for %s", projectNumber, org)
+}
+
+// GetUserProjectNodeID returns the node ID of a viewer project
+func (c *Client) GetUserProjectNodeID(ctx context.Context, projectNumber int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if project := c.findProject("user", projectNumber); project != nil {
+		return project.ID, nil
+	}
+	return "", apiError(gh.ErrProjectNotFound, "project %d not found for the current user", projectNumber)
+}
+
+// GetProjectFields returns a copy of a project's fields
+func (c *Client) GetProjectFields(ctx context.Context, projectID string) ([]gh.Field, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return nil, apiError(gh.ErrProjectNotFound, "failed to query project fields: project %s not found", projectID)
+	}
+
+	fields := make([]gh.Field, len(project.Fields))
+	for i, field := range project.Fields {
+		field.Options = append([]gh.FieldOption(nil), field.Options...)
+		fields[i] = field
+	}
+	return fields, nil
+}
+
+// CreateSingleSelectField adds a single-select field to a project
+func (c *Client) CreateSingleSelectField(ctx context.Context, projectID, fieldName string, options map[string]gh.FieldColor) (*gh.Field, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return nil, fmt.Errorf("failed to create field: project %s not found", projectID)
+	}
+	if gh.FindFieldByName(project.Fields, fieldName) != nil {
+		return nil, fmt.Errorf("failed to create field: field %s already exists", fieldName)
+	}
+
+	field := gh.Field{ID: c.newID("PVTSSF"), Name: fieldName, Options: c.buildOptions(options)}
+	project.Fields = append(project.Fields, field)
+	return &field, nil
+}
+
+// AddOptionsToField replaces a single-select field's options, keeping IDs of options that already existed
+func (c *Client) AddOptionsToField(ctx context.Context, fieldID string, options map[string]gh.FieldColor) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	field := c.findField(fieldID)
+	if field == nil {
+		return fmt.Errorf("failed to update field options: field %s not found", fieldID)
+	}
+
+	existing := make(map[string]string)
+	for _, opt := range field.Options {
+		existing[opt.Name] = opt.ID
+	}
+
+	field.Options = c.buildOptions(options)
+	for i, opt := range field.Options {
+		if id, ok := existing[opt.Name]; ok {
+			field.Options[i].ID = id
+		}
+	}
+	return nil
+}
+
+// AddIssueToProject adds an issue to a project, returning the existing item if already present
+func (c *Client) AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return "", apiError(gh.ErrProjectNotFound, "failed to add issue to project: project %s not found", projectID)
+	}
+	if _, ok := c.issues[issueNodeID]; !ok {
+		return "", apiError(gh.ErrIssueNotFound, "failed to add issue to project: issue %s not found", issueNodeID)
+	}
+
+	for _, item := range project.Items {
+		if item.ContentID == issueNodeID {
+			return item.ID, nil
+		}
+	}
+
+	item := &Item{ID: c.newID("PVTI"), ContentID: issueNodeID, FieldValues: make(map[string]string)}
+	project.Items = append(project.Items, item)
+	return item.ID, nil
+}
+
+// DeleteProjectItem removes an item from a project
+func (c *Client) DeleteProjectItem(ctx context.Context, projectID, itemID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return apiError(gh.ErrProjectNotFound, "failed to remove item from project: project %s not found", projectID)
+	}
+	for i, item := range project.Items {
+		if item.ID == itemID {
+			project.Items = append(project.Items[:i], project.Items[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to remove item from project: item %s not found", itemID)
+}
+
+// GetProjectItemID returns the project item ID for an issue, or "" if it is not in the project
+func (c *Client) GetProjectItemID(ctx context.Context, projectID, issueNodeID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return "", apiError(gh.ErrProjectNotFound, "failed to query issue project items: project %s not found", projectID)
+	}
+	if _, ok := c.issues[issueNodeID]; !ok {
+		return "", apiError(gh.ErrIssueNotFound, "failed to query issue project items: issue %s not found", issueNodeID)
+	}
+
+	for _, item := range project.Items {
+		if item.ContentID == issueNodeID {
+			return item.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// UpdateProjectItemField sets a single-select value on a project item
+func (c *Client) UpdateProjectItemField(ctx context.Context, projectID, itemID, fieldID, optionID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return fmt.Errorf("failed to update field value: project %s not found", projectID)
+	}
+
+	var field *gh.Field
+	for i := range project.Fields {
+		if project.Fields[i].ID == fieldID {
+			field = &project.Fields[i]
+		}
+	}
+	if field == nil {
+		return fmt.Errorf("failed to update field value: field %s not found", fieldID)
+	}
+
+	validOption := false
+	for _, opt := range field.Options {
+		if opt.ID == optionID {
+			validOption = true
+		}
+	}
+	if !validOption {
+		return fmt.Errorf("failed to update field value: option %s not found in field %s", optionID, field.Name)
+	}
+
+	for _, item := range project.Items {
+		if item.ID == itemID {
+			item.FieldValues[fieldID] = optionID
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to update field value: item %s not found", itemID)
+}
+
+// ListProjectItems lists the issues in a project with their field values, in the order they were added
+func (c *Client) ListProjectItems(ctx context.Context, projectID string) ([]gh.ProjectItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return nil, apiError(gh.ErrProjectNotFound, "failed to query project items: project %s not found", projectID)
+	}
+
+	items := make([]gh.ProjectItem, 0, len(project.Items))
+	for _, item := range project.Items {
+		issue, ok := c.issues[item.ContentID]
+		if !ok {
+			continue
+		}
+
+		projectItem := gh.ProjectItem{
+			ID:        item.ID,
+			Issue:     issue.Issue,
+			Owner:     issue.Owner,
+			Repo:      issue.Repo,
+			State:     issue.State,
+			Assignees: append([]string(nil), issue.Assignees...),
+			IssueType: c.issueTypeName(issue.IssueTypeID),
+			Fields:    c.fieldValues(project, item),
+		}
+		if parent, ok := c.issues[issue.Parent]; ok {
+			projectItem.Parent = parent.summary().Ref()
+		}
+		items = append(items, projectItem)
+	}
+	return items, nil
+}
+
+// AddDraftIssueToProject adds a draft issue item to a project
+func (c *Client) AddDraftIssueToProject(ctx context.Context, projectID, title, body string, assigneeIDs []string) (string, error) {
+	if title == "" {
+		return "", fmt.Errorf("title cannot be empty")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return "", apiError(gh.ErrProjectNotFound, "failed to add draft issue to project: project %s not found", projectID)
+	}
+
+	draft := &Draft{ID: c.newID("DI"), Title: title, Body: body}
+	for _, id := range assigneeIDs {
+		login := c.userLogin(id)
+		if login == "" {
+			return "", fmt.Errorf("failed to add draft issue to project: user %s not found", id)
+		}
+		draft.Assignees = append(draft.Assignees, login)
+	}
+	c.drafts[draft.ID] = draft
+
+	item := &Item{ID: c.newID("PVTI"), ContentID: draft.ID, FieldValues: make(map[string]string)}
+	project.Items = append(project.Items, item)
+	return item.ID, nil
+}
+
+// ListDraftIssues lists the draft issues in a project, in the order they were added
+func (c *Client) ListDraftIssues(ctx context.Context, projectID string) ([]gh.DraftIssue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project, ok := c.projects[projectID]
+	if !ok {
+		return nil, apiError(gh.ErrProjectNotFound, "failed to query project draft issues: project %s not found", projectID)
+	}
+
+	var drafts []gh.DraftIssue
+	for _, item := range project.Items {
+		draft, ok := c.drafts[item.ContentID]
+		if !ok {
+			continue
+		}
+		drafts = append(drafts, gh.DraftIssue{
+			ID:        item.ID,
+			Title:     draft.Title,
+			Body:      draft.Body,
+			Assignees: append([]string(nil), draft.Assignees...),
+			Fields:    c.fieldValues(project, item),
+		})
+	}
+	return drafts, nil
+}
+
+// ConvertDraftIssue turns a draft issue item into an issue with the next
+// number in the repository. The item keeps its ID and field values.
+func (c *Client) ConvertDraftIssue(ctx context.Context, itemID, repositoryID string) (*gh.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var r *Repo
+	for _, repo := range c.repos {
+		if repo.ID == repositoryID {
+			r = repo
+		}
+	}
+	if r == nil {
+		return nil, apiError(gh.ErrRepoNotFound, "failed to convert draft issue: repository %s not found", repositoryID)
+	}
+
+	for _, project := range c.projects {
+		for _, item := range project.Items {
+			if item.ID != itemID {
+				continue
+			}
+			draft, ok := c.drafts[item.ContentID]
+			if !ok {
+				return nil, fmt.Errorf("failed to convert draft issue: item %s is not a draft issue", itemID)
+			}
+
+			issue := &Issue{
+				Issue: gh.Issue{
+					ID:     c.newID("I"),
+					Number: r.nextNumber,
+					URL:    issueURL(r.Owner, r.Name, r.nextNumber),
+					Title:  draft.Title,
+					Body:   draft.Body,
+				},
+				Owner:     r.Owner,
+				Repo:      r.Name,
+				State:     "OPEN",
+				Assignees: draft.Assignees,
+			}
+			r.nextNumber++
+			c.issues[issue.ID] = issue
+			delete(c.drafts, draft.ID)
+			item.ContentID = issue.ID
+
+			result := issue.Issue
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to convert draft issue: item %s not found", itemID)
+}
+
+// SetIssueType sets the issue type of an issue to a type of any organization
+func (c *Client) SetIssueType(ctx context.Context, issueNodeID, issueTypeID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, ok := c.issues[issueNodeID]
+	if !ok {
+		return apiError(gh.ErrIssueNotFound, "failed to set issue type: issue %s not found", issueNodeID)
+	}
+	if c.issueTypeName(issueTypeID) == "" {
+		return fmt.Errorf("failed to set issue type: issue type %s not found", issueTypeID)
+	}
+	issue.IssueTypeID = issueTypeID
+	return nil
+}
+
+func (c *Client) issueTypeName(issueTypeID string) string {
+	if issueTypeID == "" {
+		return ""
+	}
+	for _, org := range c.organizations {
+		for _, issueType := range org.IssueTypes {
+			if issueType.ID == issueTypeID {
+				return issueType.Name
+			}
+		}
+	}
+	return ""
+}
+
+// GetRateLimit reports an untouched budget; the fake has no rate limits
+func (c *Client) GetRateLimit(ctx context.Context) (*gh.RateLimit, error) {
+	return &gh.RateLimit{
+		Resource:  "graphql",
+		Limit:     5000,
+		Remaining: 5000,
+		ResetAt:   time.Now().Add(time.Hour),
+	}, nil
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // Issue represents a GitHub issue
@@ -17,9 +15,18 @@ type Issue struct {
 	Body   string `json:"body"`
 }
 
+// CreateIssueOptions are the optional properties of a new issue, as node IDs
+// valid in the target repository
+type CreateIssueOptions struct {
+	IssueTypeID string
+	LabelIDs    []string
+	AssigneeIDs []string
+	MilestoneID string
+}
+
 // CreateIssue creates an issue in the specified repository and returns the issue URL
-// If issueTypeID is provided, it will set the GitHub issue type
-func CreateIssue(ctx context.Context, owner, repo, title, body, issueTypeID string) (*Issue, error) {
+// Issue type, labels, assignees and milestone are set from opts when provided
+func (c *APIClient) CreateIssue(ctx context.Context, owner, repo, title, body string, opts CreateIssueOptions) (*Issue, error) {
 	if owner == "" || repo == "" {
 		return nil, errors.New("owner and repo cannot be empty")
 	}
@@ -27,11 +34,6 @@ func CreateIssue(ctx context.Context, owner, repo, title, body, issueTypeID stri
 		return nil, errors.New("title cannot be empty")
 	}
 
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
 	// 1) Get repository ID
 	var repoQuery struct {
 		Repository struct {
@@ -52,12 +54,12 @@ func CreateIssue(ctx context.Context, owner, repo, title, body, issueTypeID stri
 		"name":  repo,
 	}
 
-	if err := client.DoWithContext(ctx, repoQueryQL, vars, &repoQuery); err != nil {
+	if err := c.graphQL.DoWithContext(ctx, repoQueryQL, vars, &repoQuery); err != nil {
 		return nil, fmt.Errorf("failed to query repository id: %w", err)
 	}
 
 	if repoQuery.Repository.ID == "" {
-		return nil, errors.New("repository not found or has no id")
+		return nil, newError(ErrRepoNotFound, "repository %s/%s not found", owner, repo)
 	}
 
 	repoID := repoQuery.Repository.ID
@@ -89,16 +91,25 @@ func CreateIssue(ctx context.Context, owner, repo, title, body, issueTypeID stri
 		"body":         body,
 	}
 
-	// Add issue type if provided
-	if issueTypeID != "" {
-		input["issueTypeId"] = issueTypeID
+	// Add optional properties if provided
+	if opts.IssueTypeID != "" {
+		input["issueTypeId"] = opts.IssueTypeID
+	}
+	if len(opts.LabelIDs) > 0 {
+		input["labelIds"] = opts.LabelIDs
+	}
+	if len(opts.AssigneeIDs) > 0 {
+		input["assigneeIds"] = opts.AssigneeIDs
+	}
+	if opts.MilestoneID != "" {
+		input["milestoneId"] = opts.MilestoneID
 	}
 
 	vars = map[string]interface{}{
 		"input": input,
 	}
 
-	if err := client.DoWithContext(ctx, createIssueQL, vars, &createResp); err != nil {
+	if err := c.graphQL.DoWithContext(ctx, createIssueQL, vars, &createResp); err != nil {
 		return nil, fmt.Errorf("failed to create issue: %w", err)
 	}
 
@@ -108,3 +119,105 @@ func CreateIssue(ctx context.Context, owner, repo, title, body, issueTypeID stri
 
 	return &createResp.CreateIssue.Issue, nil
 }
+
+// UpdateIssue changes the title and/or body of an issue. Nil values are left unchanged.
+func (c *APIClient) UpdateIssue(ctx context.Context, issueNodeID string, title, body *string) error {
+	if title == nil && body == nil {
+		return nil
+	}
+	if title != nil && *title == "" {
+		return errors.New("title cannot be empty")
+	}
+
+	mutation := `
+		mutation UpdateIssue($input: UpdateIssueInput!) {
+			updateIssue(input: $input) {
+				issue {
+					id
+				}
+			}
+		}
+	`
+
+	input := map[string]interface{}{
+		"id": issueNodeID,
+	}
+	if title != nil {
+		input["title"] = *title
+	}
+	if body != nil {
+		input["body"] = *body
+	}
+
+	var response struct {
+		UpdateIssue struct {
+			Issue struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, mutation, map[string]interface{}{"input": input}, &response)
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	return nil
+}
+
+// CloseIssue closes an issue as not planned
+func (c *APIClient) CloseIssue(ctx context.Context, issueNodeID string) error {
+	mutation := `
+		mutation($issueId: ID!) {
+			closeIssue(input: {
+				issueId: $issueId
+				stateReason: NOT_PLANNED
+			}) {
+				issue {
+					id
+				}
+			}
+		}
+	`
+
+	var response struct {
+		CloseIssue struct {
+			Issue struct {
+				ID string `json:"id"`
+			} `json:"issue"`
+		} `json:"closeIssue"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, mutation, map[string]interface{}{"issueId": issueNodeID}, &response)
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteIssue deletes an issue. It requires admin permission on the repository.
+func (c *APIClient) DeleteIssue(ctx context.Context, issueNodeID string) error {
+	mutation := `
+		mutation($issueId: ID!) {
+			deleteIssue(input: {
+				issueId: $issueId
+			}) {
+				clientMutationId
+			}
+		}
+	`
+
+	var response struct {
+		DeleteIssue struct {
+			ClientMutationID string `json:"clientMutationId"`
+		} `json:"deleteIssue"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, mutation, map[string]interface{}{"issueId": issueNodeID}, &response)
+	if err != nil {
+		return fmt.Errorf("failed to delete issue: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,471 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IssueSummary is a related issue (parent, sub-issue or dependency)
+type IssueSummary struct {
+	Owner  string
+	Repo   string
+	Number int
+	Title  string
+	State  string // OPEN or CLOSED
+}
+
+// Ref formats the issue as owner/repo#number
+func (s IssueSummary) Ref() string {
+	return fmt.Sprintf("%s/%s#%d", s.Owner, s.Repo, s.Number)
+}
+
+// ProjectFieldValues are the field values of an issue's item in one project
+type ProjectFieldValues struct {
+	ProjectID     string
+	ProjectNumber int
+	ProjectTitle  string
+	ItemID        string
+	Fields        map[string]string // Field name -> value, for every field type
+}
+
+// IssueDetails is an issue with its relationships and project field values
+type IssueDetails struct {
+	Issue
+	Owner     string
+	Repo      string
+	State     string // OPEN or CLOSED
+	IssueType string // "" if unset
+	Assignees []string
+	Labels    []string
+	Milestone string // Title, "" if unset
+	Parent    *IssueSummary
+	SubIssues []IssueSummary
+	BlockedBy []IssueSummary
+	Blocking  []IssueSummary
+	Projects  []ProjectFieldValues
+}
+
+// ProjectFields returns the issue's field values in a project
+func (d *IssueDetails) ProjectFields(projectID string) (*ProjectFieldValues, bool) {
+	for i := range d.Projects {
+		if d.Projects[i].ProjectID == projectID {
+			return &d.Projects[i], true
+		}
+	}
+	return nil, false
+}
+
+// issueDetailsQuery fetches an issue with its relationships and project items.
+// The first %s is replaced with the issueType selection, which older GitHub
+// Enterprise Server versions lack. Connections with more nodes than the first
+// page are completed with issueConnectionQuery.
+const issueDetailsQuery = `
+	query($owner: String!, $repo: String!, $number: Int!) {
+		` + rateLimitSelection + `
+		repository(owner: $owner, name: $repo) {
+			issue(number: $number) {
+				id
+				number
+				title
+				url
+				body
+				state
+				%s
+				assignees(first: 20) {
+					nodes {
+						login
+					}
+				}
+				labels(first: 50) {
+					nodes {
+						name
+					}
+				}
+				milestone {
+					title
+				}
+				parent {
+					...summary
+				}
+				subIssues(first: 100) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...summary
+					}
+				}
+				blockedBy(first: 100) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...summary
+					}
+				}
+				blocking(first: 100) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...summary
+					}
+				}
+				projectItems(first: 20, includeArchived: true) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...projectItem
+					}
+				}
+			}
+		}
+	}
+` + issueDetailsFragments
+
+// issueConnectionQuery fetches a page of one connection of an issue, aliased
+// as "connection". %s is replaced with the connection and its arguments, and
+// %s with the fragment its nodes are spread with.
+const issueConnectionQuery = `
+	query($owner: String!, $repo: String!, $number: Int!, $first: Int!, $cursor: String) {
+		repository(owner: $owner, name: $repo) {
+			issue(number: $number) {
+				connection: %s {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...%s
+					}
+				}
+			}
+		}
+	}
+` + issueDetailsFragments
+
+// issueDetailsFragments are the fragments shared by the issue details queries
+const issueDetailsFragments = `
+	fragment summary on Issue {
+		number
+		title
+		state
+		repository {
+			name
+			owner {
+				login
+			}
+		}
+	}
+
+	fragment projectItem on ProjectV2Item {
+		id
+		project {
+			id
+			number
+			title
+		}
+		fieldValues(first: 50) {
+			nodes {
+				... on ProjectV2ItemFieldSingleSelectValue {
+					name
+					field {
+						...fieldName
+					}
+				}
+				... on ProjectV2ItemFieldTextValue {
+					text
+					field {
+						...fieldName
+					}
+				}
+				... on ProjectV2ItemFieldNumberValue {
+					number
+					field {
+						...fieldName
+					}
+				}
+				... on ProjectV2ItemFieldDateValue {
+					date
+					field {
+						...fieldName
+					}
+				}
+				... on ProjectV2ItemFieldIterationValue {
+					title
+					field {
+						...fieldName
+					}
+				}
+			}
+		}
+	}
+
+	fragment fieldName on ProjectV2FieldCommon {
+		name
+	}
+`
+
+// projectItemsPageSize is the number of project items requested per page,
+// kept small as each item carries its field values
+const projectItemsPageSize = 20
+
+// issueSummaryNode is the raw shape of the summary fragment
+type issueSummaryNode struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+func (n issueSummaryNode) summary() IssueSummary {
+	return IssueSummary{
+		Owner:  n.Repository.Owner.Login,
+		Repo:   n.Repository.Name,
+		Number: n.Number,
+		Title:  n.Title,
+		State:  n.State,
+	}
+}
+
+func summaries(nodes []issueSummaryNode) []IssueSummary {
+	result := make([]IssueSummary, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, node.summary())
+	}
+	return result
+}
+
+// fieldValueNode is the raw shape of a project field value of any type
+type fieldValueNode struct {
+	Name   string   `json:"name"`
+	Text   string   `json:"text"`
+	Number *float64 `json:"number"`
+	Date   string   `json:"date"`
+	Title  string   `json:"title"`
+	Field  struct {
+		Name string `json:"name"`
+	} `json:"field"`
+}
+
+func (n fieldValueNode) value() string {
+	switch {
+	case n.Name != "":
+		return n.Name
+	case n.Text != "":
+		return n.Text
+	case n.Number != nil:
+		return strconv.FormatFloat(*n.Number, 'f', -1, 64)
+	case n.Date != "":
+		return n.Date
+	}
+	return n.Title
+}
+
+// issueProjectItemNode is the raw shape of the projectItem fragment
+type issueProjectItemNode struct {
+	ID      string `json:"id"`
+	Project struct {
+		ID     string `json:"id"`
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"project"`
+	FieldValues struct {
+		Nodes []fieldValueNode `json:"nodes"`
+	} `json:"fieldValues"`
+}
+
+// connection is the first page of a connection of an issue
+type connection[T any] struct {
+	Nodes    []T      `json:"nodes"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// GetIssueDetails fetches an issue with its type, parent, sub-issues,
+// dependencies and the field values of every project it belongs to
+func (c *APIClient) GetIssueDetails(ctx context.Context, owner, repo string, issueNumber int) (*IssueDetails, error) {
+	details, err := c.getIssueDetails(ctx, owner, repo, issueNumber, true)
+	if errors.Is(err, ErrIssueTypesUnavailable) {
+		// The server doesn't know the issueType field; fetch without it
+		details, err = c.getIssueDetails(ctx, owner, repo, issueNumber, false)
+	}
+	return details, err
+}
+
+func (c *APIClient) getIssueDetails(ctx context.Context, owner, repo string, issueNumber int, withIssueType bool) (*IssueDetails, error) {
+	issueTypeSelection := ""
+	if withIssueType {
+		issueTypeSelection = "issueType { name }"
+	}
+	query := fmt.Sprintf(issueDetailsQuery, issueTypeSelection)
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": issueNumber,
+	}
+
+	var response struct {
+		Repository *struct {
+			Issue *struct {
+				Issue
+				State     string `json:"state"`
+				IssueType *struct {
+					Name string `json:"name"`
+				} `json:"issueType"`
+				Assignees struct {
+					Nodes []struct {
+						Login string `json:"login"`
+					} `json:"nodes"`
+				} `json:"assignees"`
+				Labels struct {
+					Nodes []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+				Milestone *struct {
+					Title string `json:"title"`
+				} `json:"milestone"`
+				Parent       *issueSummaryNode                `json:"parent"`
+				SubIssues    connection[issueSummaryNode]     `json:"subIssues"`
+				BlockedBy    connection[issueSummaryNode]     `json:"blockedBy"`
+				Blocking     connection[issueSummaryNode]     `json:"blocking"`
+				ProjectItems connection[issueProjectItemNode] `json:"projectItems"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issue #%d: %w", issueNumber, err)
+	}
+	if response.Repository == nil {
+		return nil, newError(ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+	}
+	if response.Repository.Issue == nil {
+		return nil, newError(ErrIssueNotFound, "issue #%d not found in %s/%s", issueNumber, owner, repo)
+	}
+	issue := response.Repository.Issue
+
+	// Fetch the pages after the first of every connection
+	subIssues, err := remainingNodes(c, ctx, owner, repo, issueNumber, "subIssues", "summary", pageSize, issue.SubIssues)
+	if err != nil {
+		return nil, err
+	}
+	blockedBy, err := remainingNodes(c, ctx, owner, repo, issueNumber, "blockedBy", "summary", pageSize, issue.BlockedBy)
+	if err != nil {
+		return nil, err
+	}
+	blocking, err := remainingNodes(c, ctx, owner, repo, issueNumber, "blocking", "summary", pageSize, issue.Blocking)
+	if err != nil {
+		return nil, err
+	}
+	projectItems, err := remainingNodes(c, ctx, owner, repo, issueNumber, "projectItems", "projectItem", projectItemsPageSize, issue.ProjectItems)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &IssueDetails{
+		Issue:     issue.Issue,
+		Owner:     owner,
+		Repo:      repo,
+		State:     strings.ToUpper(issue.State),
+		SubIssues: summaries(subIssues),
+		BlockedBy: summaries(blockedBy),
+		Blocking:  summaries(blocking),
+	}
+	if issue.IssueType != nil {
+		details.IssueType = issue.IssueType.Name
+	}
+	for _, assignee := range issue.Assignees.Nodes {
+		details.Assignees = append(details.Assignees, assignee.Login)
+	}
+	for _, label := range issue.Labels.Nodes {
+		details.Labels = append(details.Labels, label.Name)
+	}
+	if issue.Milestone != nil {
+		details.Milestone = issue.Milestone.Title
+	}
+	if issue.Parent != nil {
+		parent := issue.Parent.summary()
+		details.Parent = &parent
+	}
+	for _, item := range projectItems {
+		values := ProjectFieldValues{
+			ProjectID:     item.Project.ID,
+			ProjectNumber: item.Project.Number,
+			ProjectTitle:  item.Project.Title,
+			ItemID:        item.ID,
+			Fields:        make(map[string]string),
+		}
+		for _, value := range item.FieldValues.Nodes {
+			if value.Field.Name != "" {
+				values.Fields[value.Field.Name] = value.value()
+			}
+		}
+		details.Projects = append(details.Projects, values)
+	}
+
+	return details, nil
+}
+
+// remainingNodes returns the nodes of a connection of an issue, fetching the
+// pages after first, which the issue details query returned
+func remainingNodes[T any](c *APIClient, ctx context.Context, owner, repo string, issueNumber int, name, fragment string, first int, page connection[T]) ([]T, error) {
+	if !page.PageInfo.HasNextPage {
+		return page.Nodes, nil
+	}
+
+	arguments := "first: $first, after: $cursor"
+	if name == "projectItems" {
+		arguments += ", includeArchived: true"
+	}
+	query := fmt.Sprintf(issueConnectionQuery, fmt.Sprintf("%s(%s)", name, arguments), fragment)
+
+	start := page.PageInfo.EndCursor
+	rest, err := paginate(func(cursor *string) ([]T, PageInfo, error) {
+		if cursor == nil {
+			cursor = &start
+		}
+		variables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"number": issueNumber,
+			"first":  first,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Repository struct {
+				Issue struct {
+					Connection connection[T] `json:"connection"`
+				} `json:"issue"`
+			} `json:"repository"`
+		}
+
+		err := c.graphQL.DoWithContext(ctx, query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query %s of issue #%d: %w", name, issueNumber, err)
+		}
+
+		result := response.Repository.Issue.Connection
+		return result.Nodes, result.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(page.Nodes, rest...), nil
+}
@@ -0,0 +1,120 @@
+package gh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// roundTripFunc serves requests with a function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestClient creates an APIClient whose GraphQL requests are answered by
+// respond, given the query and variables of each request
+func newTestClient(t *testing.T, respond func(query string, variables map[string]interface{}) string) *APIClient {
+	t.Helper()
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var payload struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(respond(payload.Query, payload.Variables))),
+			Request:    req,
+		}, nil
+	})
+
+	client, err := NewClient(api.ClientOptions{Host: "github.com", AuthToken: "test", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// summaryNodes renders issue summaries numbered from..to as JSON nodes
+func summaryNodes(from, to int) string {
+	nodes := make([]string, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		nodes = append(nodes, fmt.Sprintf(`{"number":%d,"title":"Task %d","state":"OPEN","repository":{"name":"pm","owner":{"login":"acme"}}}`, n, n))
+	}
+	return "[" + strings.Join(nodes, ",") + "]"
+}
+
+func TestGetIssueDetailsPaginatesConnections(t *testing.T) {
+	tests := []struct {
+		name      string
+		subIssues int // Sub-issues on the server, served 100 per page
+		blockedBy int
+		wantCalls int
+	}{
+		{name: "single page", subIssues: 3, blockedBy: 1, wantCalls: 1},
+		{name: "sub-issues over two pages", subIssues: 150, blockedBy: 1, wantCalls: 2},
+		{name: "several connections over three pages", subIssues: 250, blockedBy: 120, wantCalls: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// page serves the nodes of a connection after cursor, like GitHub does
+			page := func(total int, cursor interface{}) string {
+				start := 1
+				if c, ok := cursor.(string); ok {
+					fmt.Sscanf(c, "after-%d", &start)
+					start++
+				}
+				end := min(start+pageSize-1, total)
+				return fmt.Sprintf(`{"pageInfo":{"hasNextPage":%t,"endCursor":"after-%d"},"nodes":%s}`, end < total, end, summaryNodes(start, end))
+			}
+
+			calls := 0
+			client := newTestClient(t, func(query string, variables map[string]interface{}) string {
+				calls++
+				if strings.Contains(query, "connection: subIssues") {
+					return fmt.Sprintf(`{"data":{"repository":{"issue":{"connection":%s}}}}`, page(tt.subIssues, variables["cursor"]))
+				}
+				if strings.Contains(query, "connection: blockedBy") {
+					return fmt.Sprintf(`{"data":{"repository":{"issue":{"connection":%s}}}}`, page(tt.blockedBy, variables["cursor"]))
+				}
+				return fmt.Sprintf(`{"data":{"repository":{"issue":{
+					"id":"I_1","number":1,"title":"Epic","state":"OPEN",
+					"subIssues":%s,
+					"blockedBy":%s,
+					"blocking":{"pageInfo":{"hasNextPage":false},"nodes":[]},
+					"projectItems":{"pageInfo":{"hasNextPage":false},"nodes":[]}
+				}}}}`, page(tt.subIssues, nil), page(tt.blockedBy, nil))
+			})
+
+			details, err := client.GetIssueDetails(context.Background(), "acme", "pm", 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(details.SubIssues) != tt.subIssues {
+				t.Errorf("got %d sub-issues, want %d", len(details.SubIssues), tt.subIssues)
+			}
+			if last := details.SubIssues[len(details.SubIssues)-1].Number; last != tt.subIssues {
+				t.Errorf("last sub-issue is #%d, want #%d", last, tt.subIssues)
+			}
+			if len(details.BlockedBy) != tt.blockedBy {
+				t.Errorf("got %d blocking issues, want %d", len(details.BlockedBy), tt.blockedBy)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("made %d requests, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
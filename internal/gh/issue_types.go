@@ -2,41 +2,22 @@ package gh
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/Zytera/gh-project-management/internal/templates"
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
-// newIssueTypesClient creates a GraphQL client with the required headers for issue types API
-func newIssueTypesClient() (*api.GraphQLClient, error) {
-	opts := api.ClientOptions{
-		Headers: map[string]string{
-			"GraphQL-Features": "issue_types",
-		},
-		Timeout: 30 * time.Second,
-	}
-
-	client, err := api.NewGraphQLClient(opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client with issue types headers: %w", err)
-	}
-
-	return client, nil
-}
-
 // ListOrgIssueTypes lists all issue types for an organization
-func ListOrgIssueTypes(ctx context.Context, org string) ([]templates.IssueTypeConfig, error) {
-	client, err := newIssueTypesClient()
-	if err != nil {
-		return nil, err
-	}
-
+func (c *APIClient) ListOrgIssueTypes(ctx context.Context, org string) ([]templates.IssueTypeConfig, error) {
 	query := `
-		query($org: String!) {
+		query($org: String!, $first: Int!, $cursor: String) {
 			organization(login: $org) {
-				issueTypes(first: 25) {
+				issueTypes(first: $first, after: $cursor) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
 					edges {
 						node {
 							id
@@ -50,40 +31,40 @@ func ListOrgIssueTypes(ctx context.Context, org string) ([]templates.IssueTypeCo
 		}
 	`
 
-	variables := map[string]interface{}{
-		"org": org,
-	}
+	return paginate(func(cursor *string) ([]templates.IssueTypeConfig, PageInfo, error) {
+		variables := map[string]interface{}{
+			"org":    org,
+			"first":  pageSize,
+			"cursor": cursor,
+		}
 
-	var response struct {
-		Organization struct {
-			IssueTypes struct {
-				Edges []struct {
-					Node templates.IssueTypeConfig `json:"node"`
-				} `json:"edges"`
-			} `json:"issueTypes"`
-		} `json:"organization"`
-	}
+		var response struct {
+			Organization struct {
+				IssueTypes struct {
+					Edges []struct {
+						Node templates.IssueTypeConfig `json:"node"`
+					} `json:"edges"`
+					PageInfo PageInfo `json:"pageInfo"`
+				} `json:"issueTypes"`
+			} `json:"organization"`
+		}
 
-	err = client.DoWithContext(ctx, query, variables, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list issue types: %w", err)
-	}
+		err := c.issueTypes.DoWithContext(ctx, query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to list issue types: %w", err)
+		}
 
-	var issueTypes []templates.IssueTypeConfig
-	for _, edge := range response.Organization.IssueTypes.Edges {
-		issueTypes = append(issueTypes, edge.Node)
-	}
+		var issueTypes []templates.IssueTypeConfig
+		for _, edge := range response.Organization.IssueTypes.Edges {
+			issueTypes = append(issueTypes, edge.Node)
+		}
 
-	return issueTypes, nil
+		return issueTypes, response.Organization.IssueTypes.PageInfo, nil
+	})
 }
 
 // CreateIssueType creates a new issue type in the organization
-func CreateIssueType(ctx context.Context, orgID, name, description string) (*templates.IssueTypeConfig, error) {
-	client, err := newIssueTypesClient()
-	if err != nil {
-		return nil, err
-	}
-
+func (c *APIClient) CreateIssueType(ctx context.Context, orgID, name, description string) (*templates.IssueTypeConfig, error) {
 	mutation := `
 		mutation($orgId: ID!, $name: String!, $description: String) {
 			createIssueType(input: {
@@ -113,7 +94,7 @@ func CreateIssueType(ctx context.Context, orgID, name, description string) (*tem
 		} `json:"createIssueType"`
 	}
 
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err := c.issueTypes.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create issue type: %w", err)
 	}
@@ -122,12 +103,7 @@ func CreateIssueType(ctx context.Context, orgID, name, description string) (*tem
 }
 
 // GetOrgNodeID gets the organization's node ID
-func GetOrgNodeID(ctx context.Context, org string) (string, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return "", fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) GetOrgNodeID(ctx context.Context, org string) (string, error) {
 	query := `
 		query($org: String!) {
 			organization(login: $org) {
@@ -146,7 +122,7 @@ func GetOrgNodeID(ctx context.Context, org string) (string, error) {
 		} `json:"organization"`
 	}
 
-	err = client.DoWithContext(ctx, query, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
 	if err != nil {
 		return "", fmt.Errorf("failed to get organization node ID: %w", err)
 	}
@@ -154,36 +130,32 @@ func GetOrgNodeID(ctx context.Context, org string) (string, error) {
 	return response.Organization.ID, nil
 }
 
-// EnsureIssueType ensures an issue type exists, creates it if it doesn't
-func EnsureIssueType(ctx context.Context, org, issueTypeName, description string) (*templates.IssueTypeConfig, error) {
-	// First, try to list existing issue types
-	existingTypes, err := ListOrgIssueTypes(ctx, org)
+// EnsureIssueType ensures an issue type exists, creates it if it doesn't.
+// Returns an error wrapping ErrIssueTypesUnavailable if issue types are not
+// available for the org; callers treat it as a warning.
+func EnsureIssueType(ctx context.Context, client Client, org, issueTypeName, description string) (*templates.IssueTypeConfig, error) {
+	// First, check if the type already exists
+	existing, err := findIssueType(ctx, client, org, issueTypeName)
 	if err != nil {
-		// If listing fails, issue types might not be available for this org
-		// Return nil without error (we'll skip issue type assignment)
-		return nil, nil
+		return nil, err
 	}
-
-	// Check if type already exists
-	for _, issueType := range existingTypes {
-		if issueType.Name == issueTypeName {
-			return &issueType, nil
-		}
+	if existing != nil {
+		return existing, nil
 	}
 
 	// Type doesn't exist, create it
-	orgID, err := GetOrgNodeID(ctx, org)
+	orgID, err := client.GetOrgNodeID(ctx, org)
 	if err != nil {
 		return nil, err
 	}
 
-	return CreateIssueType(ctx, orgID, issueTypeName, description)
+	return client.CreateIssueType(ctx, orgID, issueTypeName, description)
 }
 
 // SyncIssueTypesWithTemplates ensures issue types exist for all templates
-func SyncIssueTypesWithTemplates(ctx context.Context, org string, templatesMap map[string]*templates.IssueTemplate) error {
+func SyncIssueTypesWithTemplates(ctx context.Context, client Client, org string, templatesMap map[string]*templates.IssueTemplate) error {
 	for typeName, template := range templatesMap {
-		_, err := EnsureIssueType(ctx, org, typeName, template.Description)
+		_, err := EnsureIssueType(ctx, client, org, typeName, template.Description)
 		if err != nil {
 			return fmt.Errorf("failed to ensure issue type %s: %w", typeName, err)
 		}
@@ -193,21 +165,42 @@ func SyncIssueTypesWithTemplates(ctx context.Context, org string, templatesMap m
 
 // GetIssueTypeIDByName gets the ID of an issue type by its name
 // Returns empty string if not found or if issue types are not available
-func GetIssueTypeIDByName(ctx context.Context, org, typeName string) (string, error) {
-	// Try to list existing issue types
-	existingTypes, err := ListOrgIssueTypes(ctx, org)
-	if err != nil {
-		// If listing fails, issue types might not be available for this org
+func GetIssueTypeIDByName(ctx context.Context, client Client, org, typeName string) (string, error) {
+	// Search for the type by name
+	issueType, err := findIssueType(ctx, client, org, typeName)
+	if errors.Is(err, ErrIssueTypesUnavailable) {
 		return "", nil
 	}
-
-	// Search for the type by name
-	for _, issueType := range existingTypes {
-		if issueType.Name == typeName && issueType.IsEnabled {
-			return issueType.ID, nil
-		}
+	if err != nil {
+		return "", err
+	}
+	if issueType != nil && issueType.IsEnabled {
+		return issueType.ID, nil
 	}
 
 	// Type not found
 	return "", nil
 }
+
+// findIssueType looks up an issue type by name, returning nil if it doesn't exist.
+// If the client caches issue types, a miss drops the cache and lists them again.
+func findIssueType(ctx context.Context, client Client, org, typeName string) (*templates.IssueTypeConfig, error) {
+	for attempt := 0; ; attempt++ {
+		existingTypes, err := client.ListOrgIssueTypes(ctx, org)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issueType := range existingTypes {
+			if issueType.Name == typeName {
+				return &issueType, nil
+			}
+		}
+
+		invalidator, ok := client.(Invalidator)
+		if attempt > 0 || !ok {
+			return nil, nil
+		}
+		invalidator.InvalidateIssueTypes(org)
+	}
+}
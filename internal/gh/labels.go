@@ -0,0 +1,206 @@
+package gh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Label is a repository label
+type Label struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"` // Hex color without "#"
+	Description string `json:"description"`
+}
+
+// ListLabels lists all labels of a repository
+func (c *APIClient) ListLabels(ctx context.Context, owner, repo string) ([]Label, error) {
+	query := `
+		query($owner: String!, $repo: String!, $first: Int!, $cursor: String) {
+			repository(owner: $owner, name: $repo) {
+				labels(first: $first, after: $cursor) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						id
+						name
+						color
+						description
+					}
+				}
+			}
+		}
+	`
+
+	return paginate(func(cursor *string) ([]Label, PageInfo, error) {
+		variables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"first":  pageSize,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Repository *struct {
+				Labels struct {
+					Nodes    []Label  `json:"nodes"`
+					PageInfo PageInfo `json:"pageInfo"`
+				} `json:"labels"`
+			} `json:"repository"`
+		}
+
+		if err := c.graphQL.DoWithContext(ctx, query, variables, &response); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to list labels: %w", err)
+		}
+		if response.Repository == nil {
+			return nil, PageInfo{}, newError(ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+		}
+
+		return response.Repository.Labels.Nodes, response.Repository.Labels.PageInfo, nil
+	})
+}
+
+// CreateLabel creates a label in a repository and returns it with its node ID
+func (c *APIClient) CreateLabel(ctx context.Context, owner, repo string, label Label) (*Label, error) {
+	payload, err := json.Marshal(map[string]string{
+		"name":        label.Name,
+		"color":       strings.TrimPrefix(label.Color, "#"),
+		"description": label.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode label: %w", err)
+	}
+
+	var response struct {
+		NodeID      string `json:"node_id"`
+		Name        string `json:"name"`
+		Color       string `json:"color"`
+		Description string `json:"description"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/labels", owner, repo)
+	if err := c.rest.Post(path, bytes.NewReader(payload), &response); err != nil {
+		return nil, fmt.Errorf("failed to create label '%s': %w", label.Name, err)
+	}
+
+	return &Label{ID: response.NodeID, Name: response.Name, Color: response.Color, Description: response.Description}, nil
+}
+
+// AddLabelsToIssue adds labels to an issue. Labels it already has are kept.
+func (c *APIClient) AddLabelsToIssue(ctx context.Context, issueNodeID string, labelIDs []string) error {
+	if len(labelIDs) == 0 {
+		return nil
+	}
+
+	mutation := `
+		mutation($labelableId: ID!, $labelIds: [ID!]!) {
+			addLabelsToLabelable(input: {
+				labelableId: $labelableId
+				labelIds: $labelIds
+			}) {
+				clientMutationId
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"labelableId": issueNodeID,
+		"labelIds":    labelIDs,
+	}
+
+	var response struct {
+		AddLabelsToLabelable struct {
+			ClientMutationID string `json:"clientMutationId"`
+		} `json:"addLabelsToLabelable"`
+	}
+
+	if err := c.graphQL.DoWithContext(ctx, mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+	return nil
+}
+
+// ResolveLabels maps label names to node IDs in a repository, ignoring case
+// like GitHub does. It returns the IDs in the order of names, and the names
+// the repository has no label for.
+func ResolveLabels(ctx context.Context, client Client, owner, repo string, names []string) ([]string, []string, error) {
+	if len(names) == 0 {
+		return nil, nil, nil
+	}
+
+	labels, err := client.ListLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids, missing []string
+	for _, name := range names {
+		if label := findLabel(labels, name); label != nil {
+			ids = append(ids, label.ID)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return ids, missing, nil
+}
+
+// ReapplyLabels adds labels to an issue that was transferred from sourceRepo
+// to targetRepo. A transfer drops the labels the target repository doesn't
+// have, so those are first created there with the color and description
+// they have in sourceRepo.
+func ReapplyLabels(ctx context.Context, client Client, owner, sourceRepo, targetRepo string, number int, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	targetLabels, err := client.ListLabels(ctx, owner, targetRepo)
+	if err != nil {
+		return err
+	}
+
+	var sourceLabels []Label
+	var ids []string
+	for _, name := range names {
+		if label := findLabel(targetLabels, name); label != nil {
+			ids = append(ids, label.ID)
+			continue
+		}
+
+		// Copy the source label, fetching the source labels only once
+		if sourceLabels == nil {
+			sourceLabels, err = client.ListLabels(ctx, owner, sourceRepo)
+			if err != nil {
+				return err
+			}
+		}
+		label := Label{Name: name, Color: "ededed"}
+		if source := findLabel(sourceLabels, name); source != nil {
+			label = *source
+		}
+
+		created, err := client.CreateLabel(ctx, owner, targetRepo, label)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, created.ID)
+	}
+
+	issueNodeID, err := client.GetIssueNodeID(ctx, owner, targetRepo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get issue node ID: %w", err)
+	}
+	return client.AddLabelsToIssue(ctx, issueNodeID, ids)
+}
+
+func findLabel(labels []Label, name string) *Label {
+	for i := range labels {
+		if strings.EqualFold(labels[i].Name, name) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
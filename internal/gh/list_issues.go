@@ -3,17 +3,10 @@ package gh
 import (
 	"context"
 	"fmt"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
-// ListRecentIssues lists recent open issues from a repository
-func ListRecentIssues(ctx context.Context, owner, repo string, limit int) ([]Issue, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+// ListRecentIssues lists the newest open issues of a repository, with their bodies
+func (c *APIClient) ListRecentIssues(ctx context.Context, owner, repo string, limit int) ([]Issue, error) {
 	query := `
 		query($owner: String!, $repo: String!, $limit: Int!) {
 			repository(owner: $owner, name: $repo) {
@@ -23,6 +16,7 @@ func ListRecentIssues(ctx context.Context, owner, repo string, limit int) ([]Iss
 						number
 						title
 						url
+						body
 					}
 				}
 			}
@@ -43,7 +37,7 @@ func ListRecentIssues(ctx context.Context, owner, repo string, limit int) ([]Iss
 		} `json:"repository"`
 	}
 
-	err = client.DoWithContext(ctx, query, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list issues: %w", err)
 	}
@@ -0,0 +1,216 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProjectItem is an issue in a project together with its project field values
+type ProjectItem struct {
+	ID        string // Project item ID
+	Issue     Issue  // Including its body
+	Owner     string
+	Repo      string
+	State     string // OPEN or CLOSED
+	UpdatedAt time.Time
+	Assignees []string
+	IssueType string            // Organization issue type, "" if unset
+	Parent    string            // owner/repo#number of the parent issue, "" if none
+	Fields    map[string]string // Single-select field name -> selected option
+}
+
+// Ref formats the item's issue as owner/repo#number
+func (i ProjectItem) Ref() string {
+	return fmt.Sprintf("%s/%s#%d", i.Owner, i.Repo, i.Issue.Number)
+}
+
+// projectItemsQuery lists the issues of a project. %s is replaced with the
+// issueType selection, which older GitHub Enterprise Server versions lack.
+const projectItemsQuery = `
+	query($projectId: ID!, $first: Int!, $cursor: String) {
+		` + rateLimitSelection + `
+		node(id: $projectId) {
+			... on ProjectV2 {
+				items(first: $first, after: $cursor) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						id
+						content {
+							... on Issue {
+								id
+								number
+								title
+								body
+								url
+								state
+								updatedAt
+								repository {
+									name
+									owner {
+										login
+									}
+								}
+								assignees(first: 10) {
+									nodes {
+										login
+									}
+								}
+								%s
+								parent {
+									number
+									repository {
+										name
+										owner {
+											login
+										}
+									}
+								}
+							}
+						}
+						fieldValues(first: 20) {
+							nodes {
+								... on ProjectV2ItemFieldSingleSelectValue {
+									name
+									field {
+										... on ProjectV2SingleSelectField {
+											name
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+`
+
+// projectItemNode is the raw shape of a project item in projectItemsQuery
+type projectItemNode struct {
+	ID      string `json:"id"`
+	Content *struct {
+		Issue
+		State      string    `json:"state"`
+		UpdatedAt  time.Time `json:"updatedAt"`
+		Repository struct {
+			Name  string `json:"name"`
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"repository"`
+		Assignees struct {
+			Nodes []struct {
+				Login string `json:"login"`
+			} `json:"nodes"`
+		} `json:"assignees"`
+		IssueType *struct {
+			Name string `json:"name"`
+		} `json:"issueType"`
+		Parent *struct {
+			Number     int `json:"number"`
+			Repository struct {
+				Name  string `json:"name"`
+				Owner struct {
+					Login string `json:"login"`
+				} `json:"owner"`
+			} `json:"repository"`
+		} `json:"parent"`
+	} `json:"content"`
+	FieldValues struct {
+		Nodes []struct {
+			Name  string `json:"name"`
+			Field struct {
+				Name string `json:"name"`
+			} `json:"field"`
+		} `json:"nodes"`
+	} `json:"fieldValues"`
+}
+
+// ListProjectItems lists the issues in a project with their single-select field values.
+// Draft issues and pull requests are skipped.
+func (c *APIClient) ListProjectItems(ctx context.Context, projectID string) ([]ProjectItem, error) {
+	items, err := c.listProjectItems(ctx, projectID, true)
+	if errors.Is(err, ErrIssueTypesUnavailable) {
+		// The server doesn't know the issueType field; list without it
+		items, err = c.listProjectItems(ctx, projectID, false)
+	}
+	return items, err
+}
+
+func (c *APIClient) listProjectItems(ctx context.Context, projectID string, withIssueType bool) ([]ProjectItem, error) {
+	issueTypeSelection := ""
+	if withIssueType {
+		issueTypeSelection = "issueType { name }"
+	}
+	query := fmt.Sprintf(projectItemsQuery, issueTypeSelection)
+
+	nodes, err := paginate(func(cursor *string) ([]projectItemNode, PageInfo, error) {
+		variables := map[string]interface{}{
+			"projectId": projectID,
+			"first":     pageSize,
+			"cursor":    cursor,
+		}
+
+		var response struct {
+			Node struct {
+				Items struct {
+					Nodes    []projectItemNode `json:"nodes"`
+					PageInfo PageInfo          `json:"pageInfo"`
+				} `json:"items"`
+			} `json:"node"`
+		}
+
+		err := c.graphQL.DoWithContext(ctx, query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query project items: %w", err)
+		}
+
+		return response.Node.Items.Nodes, response.Node.Items.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]ProjectItem, 0, len(nodes))
+	for _, node := range nodes {
+		// Non-issue content (drafts, pull requests) decodes without a number
+		if node.Content == nil || node.Content.Number == 0 {
+			continue
+		}
+		content := node.Content
+
+		item := ProjectItem{
+			ID:        node.ID,
+			Issue:     content.Issue,
+			Owner:     content.Repository.Owner.Login,
+			Repo:      content.Repository.Name,
+			State:     strings.ToUpper(content.State),
+			UpdatedAt: content.UpdatedAt,
+			Fields:    make(map[string]string),
+		}
+		for _, assignee := range content.Assignees.Nodes {
+			item.Assignees = append(item.Assignees, assignee.Login)
+		}
+		if content.IssueType != nil {
+			item.IssueType = content.IssueType.Name
+		}
+		if content.Parent != nil {
+			item.Parent = fmt.Sprintf("%s/%s#%d", content.Parent.Repository.Owner.Login, content.Parent.Repository.Name, content.Parent.Number)
+		}
+		for _, value := range node.FieldValues.Nodes {
+			if value.Field.Name != "" {
+				item.Fields[value.Field.Name] = value.Name
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,93 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Milestone is an open milestone of a repository
+type Milestone struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// ListMilestones lists the open milestones of a repository
+func (c *APIClient) ListMilestones(ctx context.Context, owner, repo string) ([]Milestone, error) {
+	query := `
+		query($owner: String!, $repo: String!, $first: Int!, $cursor: String) {
+			repository(owner: $owner, name: $repo) {
+				milestones(first: $first, after: $cursor, states: OPEN) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						id
+						number
+						title
+					}
+				}
+			}
+		}
+	`
+
+	return paginate(func(cursor *string) ([]Milestone, PageInfo, error) {
+		variables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"first":  pageSize,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Repository *struct {
+				Milestones struct {
+					Nodes    []Milestone `json:"nodes"`
+					PageInfo PageInfo    `json:"pageInfo"`
+				} `json:"milestones"`
+			} `json:"repository"`
+		}
+
+		if err := c.graphQL.DoWithContext(ctx, query, variables, &response); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to list milestones: %w", err)
+		}
+		if response.Repository == nil {
+			return nil, PageInfo{}, newError(ErrRepoNotFound, "repository %s/%s not found", owner, repo)
+		}
+
+		return response.Repository.Milestones.Nodes, response.Repository.Milestones.PageInfo, nil
+	})
+}
+
+// FindMilestone finds an open milestone of a repository by title (ignoring case) or number
+func FindMilestone(ctx context.Context, client Client, owner, repo, milestone string) (*Milestone, error) {
+	milestones, err := client.ListMilestones(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range milestones {
+		if strings.EqualFold(milestones[i].Title, milestone) {
+			return &milestones[i], nil
+		}
+	}
+	if number, err := strconv.Atoi(strings.TrimPrefix(milestone, "#")); err == nil {
+		for i := range milestones {
+			if milestones[i].Number == number {
+				return &milestones[i], nil
+			}
+		}
+	}
+
+	titles := make([]string, 0, len(milestones))
+	for _, m := range milestones {
+		titles = append(titles, m.Title)
+	}
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("milestone '%s' not found: %s/%s has no open milestones", milestone, owner, repo)
+	}
+	return nil, fmt.Errorf("milestone '%s' not found in %s/%s (open milestones: %s)", milestone, owner, repo, strings.Join(titles, ", "))
+}
@@ -3,17 +3,10 @@ package gh
 import (
 	"context"
 	"fmt"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // GetCurrentUser gets the currently authenticated user's login name
-func GetCurrentUser() (string, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return "", fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) GetCurrentUser() (string, error) {
 	query := `query { viewer { login } }`
 
 	var response struct {
@@ -22,7 +15,7 @@ func GetCurrentUser() (string, error) {
 		} `json:"viewer"`
 	}
 
-	err = client.DoWithContext(context.Background(), query, nil, &response)
+	err := c.graphQL.DoWithContext(context.Background(), query, nil, &response)
 	if err != nil {
 		return "", fmt.Errorf("error getting current user: %w", err)
 	}
@@ -30,27 +23,66 @@ func GetCurrentUser() (string, error) {
 	return response.Viewer.Login, nil
 }
 
-// ListOrganizations lists all organizations the user belongs to using GraphQL
-func ListOrganizations() ([]Organization, error) {
-	client, err := api.DefaultGraphQLClient()
+// GetUserNodeID gets the node ID of a user by login
+func (c *APIClient) GetUserNodeID(ctx context.Context, login string) (string, error) {
+	query := `query($login: String!) { user(login: $login) { id } }`
+
+	var response struct {
+		User *struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, query, map[string]interface{}{"login": login}, &response)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
+		return "", fmt.Errorf("error getting user %s: %w", login, err)
 	}
+	if response.User == nil {
+		return "", fmt.Errorf("user '%s' not found", login)
+	}
+
+	return response.User.ID, nil
+}
 
-	query := `query { viewer { organizations(first: 100) { nodes { login name } } } }`
+// ListOrganizations lists all organizations the user belongs to using GraphQL
+func (c *APIClient) ListOrganizations() ([]Organization, error) {
+	query := `query($first: Int!, $cursor: String) { viewer { organizations(first: $first, after: $cursor) { nodes { login name } pageInfo { hasNextPage endCursor } } } }`
 
-	var response struct {
-		Viewer struct {
-			Organizations struct {
-				Nodes []Organization `json:"nodes"`
-			} `json:"organizations"`
-		} `json:"viewer"`
-	}
+	return paginate(func(cursor *string) ([]Organization, PageInfo, error) {
+		variables := map[string]interface{}{
+			"first":  pageSize,
+			"cursor": cursor,
+		}
 
-	err = client.DoWithContext(context.Background(), query, nil, &response)
+		var response struct {
+			Viewer struct {
+				Organizations struct {
+					Nodes    []Organization `json:"nodes"`
+					PageInfo PageInfo       `json:"pageInfo"`
+				} `json:"organizations"`
+			} `json:"viewer"`
+		}
+
+		err := c.graphQL.DoWithContext(context.Background(), query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("error listing organizations: %w", err)
+		}
+
+		return response.Viewer.Organizations.Nodes, response.Viewer.Organizations.PageInfo, nil
+	})
+}
+
+// GetTokenScopes returns the OAuth scopes granted to the token.
+// Returns nil if the token doesn't report scopes (fine-grained and GitHub App tokens).
+func (c *APIClient) GetTokenScopes(ctx context.Context) ([]string, error) {
+	resp, err := c.rest.RequestWithContext(ctx, "GET", "user", nil)
 	if err != nil {
-		return nil, fmt.Errorf("error listing organizations: %w", err)
+		return nil, fmt.Errorf("failed to get token scopes: %w", err)
 	}
+	defer resp.Body.Close()
 
-	return response.Viewer.Organizations.Nodes, nil
+	if _, ok := resp.Header["X-Oauth-Scopes"]; !ok {
+		return nil, nil
+	}
+	return append([]string{}, splitScopes(resp.Header.Get("X-OAuth-Scopes"))...), nil
 }
@@ -0,0 +1,32 @@
+package gh
+
+// pageSize is the number of nodes requested per page (the GraphQL API maximum)
+const pageSize = 100
+
+// PageInfo is the pagination state returned with a GraphQL connection
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// paginate calls fetchPage with successive cursors until the connection has no
+// next page, and returns the nodes of all pages. The first call receives a nil
+// cursor, which is sent as a null `after` argument.
+func paginate[T any](fetchPage func(cursor *string) ([]T, PageInfo, error)) ([]T, error) {
+	var all []T
+	var cursor *string
+
+	for {
+		nodes, pageInfo, err := fetchPage(cursor)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, nodes...)
+
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			return all, nil
+		}
+		next := pageInfo.EndCursor
+		cursor = &next
+	}
+}
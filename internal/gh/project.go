@@ -4,38 +4,39 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // ListOrgProjects lists projects owned by an organization using GraphQL
-func ListOrgProjects(org string) ([]Project, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
-	query := `query($owner: String!) { organization(login: $owner) { projectsV2(first: 100) { nodes { id number title } } } }`
+func (c *APIClient) ListOrgProjects(org string) ([]Project, error) {
+	query := `query($owner: String!, $first: Int!, $cursor: String) { organization(login: $owner) { projectsV2(first: $first, after: $cursor) { nodes { id number title } pageInfo { hasNextPage endCursor } } } }`
+
+	projects, err := paginate(func(cursor *string) ([]Project, PageInfo, error) {
+		variables := map[string]interface{}{
+			"owner":  org,
+			"first":  pageSize,
+			"cursor": cursor,
+		}
 
-	variables := map[string]interface{}{
-		"owner": org,
-	}
+		var response struct {
+			Organization struct {
+				ProjectsV2 struct {
+					Nodes    []Project `json:"nodes"`
+					PageInfo PageInfo  `json:"pageInfo"`
+				} `json:"projectsV2"`
+			} `json:"organization"`
+		}
 
-	var response struct {
-		Organization struct {
-			ProjectsV2 struct {
-				Nodes []Project `json:"nodes"`
-			} `json:"projectsV2"`
-		} `json:"organization"`
-	}
+		err := c.graphQL.DoWithContext(context.Background(), query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("error listing projects for org %s: %w", org, err)
+		}
 
-	err = client.DoWithContext(context.Background(), query, variables, &response)
+		return response.Organization.ProjectsV2.Nodes, response.Organization.ProjectsV2.PageInfo, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error listing projects for org %s: %w", org, err)
+		return nil, err
 	}
 
-	projects := response.Organization.ProjectsV2.Nodes
-
 	// Mark owner
 	for i := range projects {
 		projects[i].Owner = org
@@ -45,29 +46,35 @@ func ListOrgProjects(org string) ([]Project, error) {
 }
 
 // ListUserProjects lists projects owned by the authenticated user using GraphQL
-func ListUserProjects() ([]Project, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
+func (c *APIClient) ListUserProjects() ([]Project, error) {
+	query := `query($first: Int!, $cursor: String) { viewer { projectsV2(first: $first, after: $cursor) { nodes { id number title } pageInfo { hasNextPage endCursor } } } }`
 
-	query := `query { viewer { projectsV2(first: 100) { nodes { id number title } } } }`
+	projects, err := paginate(func(cursor *string) ([]Project, PageInfo, error) {
+		variables := map[string]interface{}{
+			"first":  pageSize,
+			"cursor": cursor,
+		}
 
-	var response struct {
-		Viewer struct {
-			ProjectsV2 struct {
-				Nodes []Project `json:"nodes"`
-			} `json:"projectsV2"`
-		} `json:"viewer"`
-	}
+		var response struct {
+			Viewer struct {
+				ProjectsV2 struct {
+					Nodes    []Project `json:"nodes"`
+					PageInfo PageInfo  `json:"pageInfo"`
+				} `json:"projectsV2"`
+			} `json:"viewer"`
+		}
 
-	err = client.DoWithContext(context.Background(), query, nil, &response)
+		err := c.graphQL.DoWithContext(context.Background(), query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("error listing user projects: %w", err)
+		}
+
+		return response.Viewer.ProjectsV2.Nodes, response.Viewer.ProjectsV2.PageInfo, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error listing user projects: %w", err)
+		return nil, err
 	}
 
-	projects := response.Viewer.ProjectsV2.Nodes
-
 	// Mark as user projects
 	for i := range projects {
 		projects[i].Owner = "user"
@@ -86,17 +93,16 @@ func FormatProjectDisplay(p Project) string {
 }
 
 // GetProjectFields retrieves all fields for a project
-func GetProjectFields(ctx context.Context, projectID string) ([]Field, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) GetProjectFields(ctx context.Context, projectID string) ([]Field, error) {
 	query := `
-		query($projectId: ID!) {
+		query($projectId: ID!, $first: Int!, $cursor: String) {
 			node(id: $projectId) {
 				... on ProjectV2 {
-					fields(first: 100) {
+					fields(first: $first, after: $cursor) {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
 						nodes {
 							... on ProjectV2FieldCommon {
 								id
@@ -118,26 +124,36 @@ func GetProjectFields(ctx context.Context, projectID string) ([]Field, error) {
 		}
 	`
 
-	variables := map[string]interface{}{
-		"projectId": projectID,
-	}
+	nodes, err := paginate(func(cursor *string) ([]json.RawMessage, PageInfo, error) {
+		variables := map[string]interface{}{
+			"projectId": projectID,
+			"first":     pageSize,
+			"cursor":    cursor,
+		}
 
-	var response struct {
-		Node struct {
-			Fields struct {
-				Nodes []json.RawMessage `json:"nodes"`
-			} `json:"fields"`
-		} `json:"node"`
-	}
+		var response struct {
+			Node struct {
+				Fields struct {
+					Nodes    []json.RawMessage `json:"nodes"`
+					PageInfo PageInfo          `json:"pageInfo"`
+				} `json:"fields"`
+			} `json:"node"`
+		}
 
-	err = client.DoWithContext(ctx, query, variables, &response)
+		err := c.graphQL.DoWithContext(ctx, query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query project fields: %w", err)
+		}
+
+		return response.Node.Fields.Nodes, response.Node.Fields.PageInfo, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query project fields: %w", err)
+		return nil, err
 	}
 
 	// Parse fields from raw messages
 	fields := make([]Field, 0)
-	for _, raw := range response.Node.Fields.Nodes {
+	for _, raw := range nodes {
 		var field Field
 		if err := json.Unmarshal(raw, &field); err != nil {
 			continue // Skip fields we can't parse
@@ -158,13 +174,38 @@ func FindFieldByName(fields []Field, name string) *Field {
 	return nil
 }
 
-// AddOptionsToField adds new options to an existing single-select field
-func AddOptionsToField(ctx context.Context, fieldID string, options map[string]FieldColor) error {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return fmt.Errorf("failed to create GraphQL client: %w", err)
+// FindFieldOption finds a single-select field and the ID of one of its options.
+// If the field or option is missing and the client caches fields, the cache
+// is dropped and the fields are fetched again before giving up.
+func FindFieldOption(ctx context.Context, client Client, projectID, fieldName, optionName string) (*Field, string, error) {
+	for attempt := 0; ; attempt++ {
+		fields, err := client.GetProjectFields(ctx, projectID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		field := FindFieldByName(fields, fieldName)
+		if field != nil {
+			for _, opt := range field.Options {
+				if opt.Name == optionName {
+					return field, opt.ID, nil
+				}
+			}
+		}
+
+		invalidator, ok := client.(Invalidator)
+		if attempt > 0 || !ok {
+			if field == nil {
+				return nil, "", fmt.Errorf("%s field not found in project", fieldName)
+			}
+			return nil, "", fmt.Errorf("value '%s' not found in %s field options", optionName, fieldName)
+		}
+		invalidator.InvalidateProjectFields(projectID)
 	}
+}
 
+// AddOptionsToField adds new options to an existing single-select field
+func (c *APIClient) AddOptionsToField(ctx context.Context, fieldID string, options map[string]FieldColor) error {
 	// Build options array
 	singleSelectOptions := make([]map[string]string, 0, len(options))
 	for name, color := range options {
@@ -202,7 +243,7 @@ func AddOptionsToField(ctx context.Context, fieldID string, options map[string]F
 		} `json:"updateProjectV2Field"`
 	}
 
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return fmt.Errorf("failed to update field options: %w", err)
 	}
@@ -211,12 +252,7 @@ func AddOptionsToField(ctx context.Context, fieldID string, options map[string]F
 }
 
 // CreateSingleSelectField creates a new single-select field with options
-func CreateSingleSelectField(ctx context.Context, projectID, fieldName string, options map[string]FieldColor) (*Field, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) CreateSingleSelectField(ctx context.Context, projectID, fieldName string, options map[string]FieldColor) (*Field, error) {
 	// Build options array
 	singleSelectOptions := make([]map[string]string, 0, len(options))
 	for name, color := range options {
@@ -263,7 +299,7 @@ func CreateSingleSelectField(ctx context.Context, projectID, fieldName string, o
 		} `json:"createProjectV2Field"`
 	}
 
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create field: %w", err)
 	}
@@ -273,9 +309,9 @@ func CreateSingleSelectField(ctx context.Context, projectID, fieldName string, o
 
 // EnsureTeamField checks if the Team field exists, creates it if it doesn't,
 // and ensures all team options are present
-func EnsureTeamField(ctx context.Context, projectID string, teams map[string]string) (*Field, error) {
+func EnsureTeamField(ctx context.Context, client Client, projectID string, teams map[string]string) (*Field, error) {
 	// First, check if field exists
-	fields, err := GetProjectFields(ctx, projectID)
+	fields, err := client.GetProjectFields(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project fields: %w", err)
 	}
@@ -293,7 +329,7 @@ func EnsureTeamField(ctx context.Context, projectID string, teams map[string]str
 
 	// If field doesn't exist, create it
 	if existingField == nil {
-		newField, err := CreateSingleSelectField(ctx, projectID, "Team", teamOptions)
+		newField, err := client.CreateSingleSelectField(ctx, projectID, "Team", teamOptions)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Team field: %w", err)
 		}
@@ -333,12 +369,12 @@ func EnsureTeamField(ctx context.Context, projectID string, teams map[string]str
 			allOptions[name] = color
 		}
 
-		if err := AddOptionsToField(ctx, existingField.ID, allOptions); err != nil {
+		if err := client.AddOptionsToField(ctx, existingField.ID, allOptions); err != nil {
 			return nil, fmt.Errorf("failed to add missing team options: %w", err)
 		}
 
 		// Refresh field data
-		fields, err = GetProjectFields(ctx, projectID)
+		fields, err = client.GetProjectFields(ctx, projectID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to refresh project fields: %w", err)
 		}
@@ -350,9 +386,9 @@ func EnsureTeamField(ctx context.Context, projectID string, teams map[string]str
 
 // EnsurePriorityField checks if the Priority field exists, creates it if it doesn't,
 // and ensures all priority options are present with correct values
-func EnsurePriorityField(ctx context.Context, projectID string) (*Field, error) {
+func EnsurePriorityField(ctx context.Context, client Client, projectID string) (*Field, error) {
 	// First, check if field exists
-	fields, err := GetProjectFields(ctx, projectID)
+	fields, err := client.GetProjectFields(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project fields: %w", err)
 	}
@@ -362,7 +398,7 @@ func EnsurePriorityField(ctx context.Context, projectID string) (*Field, error)
 
 	// If field doesn't exist, create it
 	if existingField == nil {
-		newField, err := CreateSingleSelectField(ctx, projectID, "Priority", PriorityLevels)
+		newField, err := client.CreateSingleSelectField(ctx, projectID, "Priority", PriorityLevels)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Priority field: %w", err)
 		}
@@ -408,12 +444,12 @@ func EnsurePriorityField(ctx context.Context, projectID string) (*Field, error)
 			allOptions[name] = color
 		}
 
-		if err := AddOptionsToField(ctx, existingField.ID, allOptions); err != nil {
+		if err := client.AddOptionsToField(ctx, existingField.ID, allOptions); err != nil {
 			return nil, fmt.Errorf("failed to add missing priority options: %w", err)
 		}
 
 		// Refresh field data
-		fields, err = GetProjectFields(ctx, projectID)
+		fields, err = client.GetProjectFields(ctx, projectID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to refresh project fields: %w", err)
 		}
@@ -3,17 +3,10 @@ package gh
 import (
 	"context"
 	"fmt"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // AddIssueToProject adds an issue to a GitHub Project V2
-func AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (string, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return "", fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (string, error) {
 	mutation := `
 		mutation($projectId: ID!, $contentId: ID!) {
 			addProjectV2ItemById(input: {
@@ -40,7 +33,7 @@ func AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (stri
 		} `json:"addProjectV2ItemById"`
 	}
 
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return "", fmt.Errorf("failed to add issue to project: %w", err)
 	}
@@ -48,13 +41,40 @@ func AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (stri
 	return response.AddProjectV2ItemById.Item.ID, nil
 }
 
-// GetProjectNodeID gets the node ID of a project by organization and project number
-func GetProjectNodeID(ctx context.Context, org string, projectNumber int) (string, error) {
-	client, err := api.DefaultGraphQLClient()
+// DeleteProjectItem removes an item from a GitHub Project V2. The issue itself is kept.
+func (c *APIClient) DeleteProjectItem(ctx context.Context, projectID, itemID string) error {
+	mutation := `
+		mutation($projectId: ID!, $itemId: ID!) {
+			deleteProjectV2Item(input: {
+				projectId: $projectId
+				itemId: $itemId
+			}) {
+				deletedItemId
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    itemID,
+	}
+
+	var response struct {
+		DeleteProjectV2Item struct {
+			DeletedItemID string `json:"deletedItemId"`
+		} `json:"deleteProjectV2Item"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
-		return "", fmt.Errorf("failed to create GraphQL client: %w", err)
+		return fmt.Errorf("failed to remove item from project: %w", err)
 	}
 
+	return nil
+}
+
+// GetProjectNodeID gets the node ID of a project by organization and project number
+func (c *APIClient) GetProjectNodeID(ctx context.Context, org string, projectNumber int) (string, error) {
 	query := `
 		query($org: String!, $number: Int!) {
 			organization(login: $org) {
@@ -78,21 +98,20 @@ func GetProjectNodeID(ctx context.Context, org string, projectNumber int) (strin
 		} `json:"organization"`
 	}
 
-	err = client.DoWithContext(ctx, query, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
 	if err != nil {
 		return "", fmt.Errorf("failed to get project node ID: %w", err)
 	}
 
+	if response.Organization.ProjectV2.ID == "" {
+		return "", newError(ErrProjectNotFound, "project %d not found for %s", projectNumber, org)
+	}
+
 	return response.Organization.ProjectV2.ID, nil
 }
 
 // GetUserProjectNodeID gets the node ID of a user project by project number
-func GetUserProjectNodeID(ctx context.Context, projectNumber int) (string, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return "", fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) GetUserProjectNodeID(ctx context.Context, projectNumber int) (string, error) {
 	query := `
 		query($number: Int!) {
 			viewer {
@@ -115,32 +134,36 @@ func GetUserProjectNodeID(ctx context.Context, projectNumber int) (string, error
 		} `json:"viewer"`
 	}
 
-	err = client.DoWithContext(ctx, query, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
 	if err != nil {
 		return "", fmt.Errorf("failed to get user project node ID: %w", err)
 	}
 
+	if response.Viewer.ProjectV2.ID == "" {
+		return "", newError(ErrProjectNotFound, "project %d not found for the current user", projectNumber)
+	}
+
 	return response.Viewer.ProjectV2.ID, nil
 }
 
-// GetProjectItemID gets the project item ID for an issue in a project
-func GetProjectItemID(ctx context.Context, projectID, issueNodeID string) (string, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return "", fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+// GetProjectItemID gets the project item ID for an issue in a project, or ""
+// if the issue is not in the project. It reads the issue's own projectItems
+// connection, as an issue belongs to few projects while a project can hold
+// thousands of items. See EnsureProjectItem to add a missing issue.
+func (c *APIClient) GetProjectItemID(ctx context.Context, projectID, issueNodeID string) (string, error) {
 	query := `
-		query($projectId: ID!) {
-			node(id: $projectId) {
-				... on ProjectV2 {
-					items(first: 100) {
+		query($issueId: ID!, $first: Int!, $cursor: String) {
+			node(id: $issueId) {
+				... on Issue {
+					projectItems(first: $first, after: $cursor, includeArchived: true) {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
 						nodes {
 							id
-							content {
-								... on Issue {
-									id
-								}
+							project {
+								id
 							}
 						}
 					}
@@ -149,45 +172,70 @@ func GetProjectItemID(ctx context.Context, projectID, issueNodeID string) (strin
 		}
 	`
 
-	variables := map[string]interface{}{
-		"projectId": projectID,
+	type projectItem struct {
+		ID      string `json:"id"`
+		Project struct {
+			ID string `json:"id"`
+		} `json:"project"`
 	}
 
-	var response struct {
-		Node struct {
-			Items struct {
-				Nodes []struct {
-					ID      string `json:"id"`
-					Content struct {
-						ID string `json:"id"`
-					} `json:"content"`
-				} `json:"nodes"`
-			} `json:"items"`
-		} `json:"node"`
-	}
-
-	err = client.DoWithContext(ctx, query, variables, &response)
+	items, err := paginate(func(cursor *string) ([]projectItem, PageInfo, error) {
+		variables := map[string]interface{}{
+			"issueId": issueNodeID,
+			"first":   pageSize,
+			"cursor":  cursor,
+		}
+
+		var response struct {
+			Node struct {
+				ProjectItems struct {
+					Nodes    []projectItem `json:"nodes"`
+					PageInfo PageInfo      `json:"pageInfo"`
+				} `json:"projectItems"`
+			} `json:"node"`
+		}
+
+		err := c.graphQL.DoWithContext(ctx, query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query issue project items: %w", err)
+		}
+
+		return response.Node.ProjectItems.Nodes, response.Node.ProjectItems.PageInfo, nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to query project items: %w", err)
+		return "", err
 	}
 
-	// Find the item with matching issue ID
-	for _, item := range response.Node.Items.Nodes {
-		if item.Content.ID == issueNodeID {
+	// Find the item belonging to the requested project
+	for _, item := range items {
+		if item.Project.ID == projectID {
 			return item.ID, nil
 		}
 	}
 
-	return "", fmt.Errorf("issue not found in project")
+	return "", nil
 }
 
-// UpdateProjectItemField updates a single-select field value for a project item
-func UpdateProjectItemField(ctx context.Context, projectID, itemID, fieldID, optionID string) error {
-	client, err := api.DefaultGraphQLClient()
+// EnsureProjectItem returns the project item ID of an issue, adding the issue
+// to the project if it is not there yet
+func EnsureProjectItem(ctx context.Context, client Client, projectID, issueNodeID string) (string, error) {
+	itemID, err := client.GetProjectItemID(ctx, projectID, issueNodeID)
 	if err != nil {
-		return fmt.Errorf("failed to create GraphQL client: %w", err)
+		return "", err
 	}
+	if itemID != "" {
+		return itemID, nil
+	}
+
+	itemID, err = client.AddIssueToProject(ctx, projectID, issueNodeID)
+	if err != nil {
+		return "", fmt.Errorf("issue not found in project and could not be added: %w", err)
+	}
+	return itemID, nil
+}
 
+// UpdateProjectItemField updates a single-select field value for a project item
+func (c *APIClient) UpdateProjectItemField(ctx context.Context, projectID, itemID, fieldID, optionID string) error {
 	mutation := `
 		mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $optionId: String!) {
 			updateProjectV2ItemFieldValue(input: {
@@ -218,7 +266,7 @@ func UpdateProjectItemField(ctx context.Context, projectID, itemID, fieldID, opt
 		} `json:"updateProjectV2ItemFieldValue"`
 	}
 
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return fmt.Errorf("failed to update field value: %w", err)
 	}
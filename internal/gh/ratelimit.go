@@ -0,0 +1,350 @@
+package gh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetries is the number of times a request is retried after a rate limit or transient error
+	maxRetries = 3
+	// maxRateLimitWait is the longest we wait for a rate limit to reset before giving up
+	maxRateLimitWait = 5 * time.Minute
+	// defaultSecondaryWait is used when a secondary rate limit response has no Retry-After header
+	defaultSecondaryWait = time.Minute
+	// transientBackoff is the initial wait before retrying a 502/503/504, doubled on each attempt
+	transientBackoff = time.Second
+)
+
+// rateLimitSelection is added to bulk GraphQL queries so their responses
+// report what they cost; the transport records it with the headers
+const rateLimitSelection = `rateLimit { cost remaining }`
+
+// RateLimit is a snapshot of a GitHub API rate limit budget
+type RateLimit struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Cost      int       `json:"cost"` // Points of the last query that selected rateLimit
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// Low reports whether less than 10% of the budget is left
+func (r RateLimit) Low() bool {
+	return r.Limit > 0 && r.Remaining*10 < r.Limit
+}
+
+// rateLimitTransport retries requests that hit GitHub rate limits or transient
+// server errors and remembers the last rate limit headers seen per resource
+type rateLimitTransport struct {
+	next  http.RoundTripper
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu      sync.Mutex
+	latest  map[string]RateLimit
+	low     map[string]bool // Resources reported to onLow since their budget got low
+	onRetry func(message string)
+	onLow   func(limit RateLimit)
+}
+
+func newRateLimitTransport(next http.RoundTripper) *rateLimitTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitTransport{
+		next:   next,
+		sleep:  sleepContext,
+		latest: make(map[string]RateLimit),
+		low:    make(map[string]bool),
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RoundTrip sends the request, waiting and retrying on rate limits and,
+// for idempotent requests, on 502/503/504 responses and network errors
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	idempotent := isIdempotent(req, body)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil {
+			if !idempotent || attempt >= maxRetries {
+				return nil, err
+			}
+			if err := t.wait(req.Context(), transientBackoff<<attempt, "network error"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		t.record(resp.Header)
+		if resp.StatusCode == http.StatusOK && bytes.Contains(body, []byte("rateLimit")) {
+			if err := t.recordCost(resp); err != nil {
+				return nil, err
+			}
+		}
+		t.reportLow()
+
+		if attempt >= maxRetries {
+			return resp, nil
+		}
+
+		wait, reason, err := retryDelay(resp, idempotent, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if wait == 0 || wait > maxRateLimitWait {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		if err := t.wait(req.Context(), wait, reason); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *rateLimitTransport) wait(ctx context.Context, d time.Duration, reason string) error {
+	t.mu.Lock()
+	onRetry := t.onRetry
+	t.mu.Unlock()
+
+	if onRetry != nil {
+		onRetry(fmt.Sprintf("%s, retrying in %s...", reason, d.Round(time.Second)))
+	}
+	return t.sleep(ctx, d)
+}
+
+// retryDelay decides whether a response should be retried and how long to wait.
+// A zero duration means the response is returned as is.
+func retryDelay(resp *http.Response, idempotent bool, attempt int) (time.Duration, string, error) {
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				return time.Duration(seconds) * time.Second, "Secondary rate limit hit", nil
+			}
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			return untilReset(resp.Header), "Rate limit exhausted", nil
+		}
+		body, err := peekBody(resp)
+		if err != nil {
+			return 0, "", err
+		}
+		if strings.Contains(strings.ToLower(string(body)), "secondary rate limit") {
+			return defaultSecondaryWait << attempt, "Secondary rate limit hit", nil
+		}
+
+	case http.StatusOK:
+		// GraphQL reports an exhausted primary limit as a 200 with a RATE_LIMITED error
+		if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+			return 0, "", nil
+		}
+		body, err := peekBody(resp)
+		if err != nil {
+			return 0, "", err
+		}
+		if bytes.Contains(body, []byte(`"RATE_LIMITED"`)) {
+			return untilReset(resp.Header), "GraphQL rate limit exhausted", nil
+		}
+
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if idempotent {
+			return transientBackoff << attempt, fmt.Sprintf("GitHub returned %d", resp.StatusCode), nil
+		}
+	}
+
+	return 0, "", nil
+}
+
+// peekBody reads the response body and puts it back so the caller can still decode it
+func peekBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// untilReset returns the time until X-RateLimit-Reset, or zero if unknown
+func untilReset(header http.Header) time.Duration {
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	wait := time.Until(time.Unix(reset, 0)) + time.Second
+	if wait < time.Second {
+		wait = time.Second
+	}
+	return wait
+}
+
+// isIdempotent reports whether a request can be safely repeated:
+// REST reads and GraphQL queries, but not GraphQL mutations
+func isIdempotent(req *http.Request, body []byte) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		if !strings.HasSuffix(req.URL.Path, "/graphql") {
+			return false
+		}
+		var payload struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return false
+		}
+		return !strings.HasPrefix(strings.TrimSpace(payload.Query), "mutation")
+	}
+	return false
+}
+
+// record stores the rate limit headers of a response
+func (t *rateLimitTransport) record(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	used, _ := strconv.Atoi(header.Get("X-RateLimit-Used"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	resource := header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.latest[resource] = RateLimit{
+		Resource:  resource,
+		Limit:     limit,
+		Cost:      t.latest[resource].Cost,
+		Remaining: remaining,
+		Used:      used,
+		ResetAt:   time.Unix(reset, 0),
+	}
+}
+
+// recordCost stores the cost of a GraphQL query that selected rateLimit,
+// read from the response body
+func (t *rateLimitTransport) recordCost(resp *http.Response) error {
+	body, err := peekBody(resp)
+	if err != nil {
+		return err
+	}
+
+	var payload struct {
+		Data struct {
+			RateLimit *struct {
+				Cost      int `json:"cost"`
+				Remaining int `json:"remaining"`
+			} `json:"rateLimit"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Data.RateLimit == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	latest := t.latest["graphql"]
+	latest.Resource = "graphql"
+	latest.Cost = payload.Data.RateLimit.Cost
+	latest.Remaining = payload.Data.RateLimit.Remaining
+	t.latest["graphql"] = latest
+	return nil
+}
+
+// reportLow tells onLow about each resource whose budget just got low
+func (t *rateLimitTransport) reportLow() {
+	t.mu.Lock()
+	var low []RateLimit
+	for resource, limit := range t.latest {
+		if !limit.Low() {
+			delete(t.low, resource)
+		} else if !t.low[resource] && t.onLow != nil {
+			t.low[resource] = true
+			low = append(low, limit)
+		}
+	}
+	onLow := t.onLow
+	t.mu.Unlock()
+
+	for _, limit := range low {
+		onLow(limit)
+	}
+}
+
+// OnRetry sets the function told about each wait before a retry, with a
+// message like "Secondary rate limit hit, retrying in 1m0s...". By default
+// retries are silent.
+func (c *APIClient) OnRetry(fn func(message string)) {
+	c.rateLimits.mu.Lock()
+	defer c.rateLimits.mu.Unlock()
+	c.rateLimits.onRetry = fn
+}
+
+// OnLowRateLimit sets the function told when less than 10% of the budget of
+// an API resource is left, once until the budget recovers. The limit has the
+// cost of the last bulk GraphQL query. By default nothing is reported.
+func (c *APIClient) OnLowRateLimit(fn func(limit RateLimit)) {
+	c.rateLimits.mu.Lock()
+	defer c.rateLimits.mu.Unlock()
+	c.rateLimits.onLow = fn
+}
+
+// GetRateLimit queries the remaining GraphQL budget
+func (c *APIClient) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	query := `query { rateLimit { limit cost remaining used resetAt } }`
+
+	var response struct {
+		RateLimit RateLimit `json:"rateLimit"`
+	}
+
+	err := c.graphQL.DoWithContext(ctx, query, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rate limit: %w", err)
+	}
+
+	response.RateLimit.Resource = "graphql"
+	return &response.RateLimit, nil
+}
@@ -0,0 +1,114 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestLowRateLimitIsReportedOnce(t *testing.T) {
+	remaining := []int{600, 400, 300, 4999, 100}
+	calls := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		if !strings.Contains(string(body), "rateLimit") {
+			t.Errorf("bulk query doesn't select rateLimit: %s", body)
+		}
+		left := remaining[calls]
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":          []string{"application/json"},
+				"X-Ratelimit-Limit":     []string{"5000"},
+				"X-Ratelimit-Remaining": []string{strconv.Itoa(left)},
+				"X-Ratelimit-Resource":  []string{"graphql"},
+			},
+			Body:    io.NopCloser(strings.NewReader(fmt.Sprintf(`{"data":{"rateLimit":{"cost":7,"remaining":%d},"node":{"items":{"pageInfo":{"hasNextPage":false},"nodes":[]}}}}`, left))),
+			Request: req,
+		}, nil
+	})
+	client, err := NewClient(api.ClientOptions{Host: "github.com", AuthToken: "test", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []RateLimit
+	client.OnLowRateLimit(func(limit RateLimit) { reported = append(reported, limit) })
+	for range remaining {
+		if _, err := client.ListDraftIssues(context.Background(), "PVT_1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reported when the budget gets low, and again after it recovered
+	want := []RateLimit{
+		{Resource: "graphql", Limit: 5000, Cost: 7, Remaining: 400},
+		{Resource: "graphql", Limit: 5000, Cost: 7, Remaining: 100},
+	}
+	if len(reported) != len(want) {
+		t.Fatalf("reported %+v, want %+v", reported, want)
+	}
+	for i := range want {
+		got := reported[i]
+		if got.Resource != want[i].Resource || got.Limit != want[i].Limit || got.Cost != want[i].Cost || got.Remaining != want[i].Remaining {
+			t.Errorf("report %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestRetriesAreReportedThroughOnRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		onRetry  bool
+		statuses []int
+		want     []string
+	}{
+		{name: "silent by default", statuses: []int{http.StatusBadGateway, http.StatusOK}},
+		{name: "reported when set", onRetry: true, statuses: []int{http.StatusBadGateway, http.StatusOK}, want: []string{"GitHub returned 502, retrying in 1s..."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				status := tt.statuses[calls]
+				calls++
+				return &http.Response{
+					StatusCode: status,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"viewer":{"login":"me"}}}`)),
+					Request:    req,
+				}, nil
+			})
+			client, err := NewClient(api.ClientOptions{Host: "github.com", AuthToken: "test", Transport: transport})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.rateLimits.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+			var messages []string
+			if tt.onRetry {
+				client.OnRetry(func(message string) { messages = append(messages, message) })
+			}
+
+			var response struct{}
+			if err := client.graphQL.DoWithContext(context.Background(), `query { viewer { login } }`, nil, &response); err != nil {
+				t.Fatal(err)
+			}
+			if calls != len(tt.statuses) {
+				t.Errorf("made %d requests, want %d", calls, len(tt.statuses))
+			}
+			if strings.Join(messages, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got retry messages %q, want %q", messages, tt.want)
+			}
+		})
+	}
+}
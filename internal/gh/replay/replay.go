@@ -0,0 +1,237 @@
+// Package replay records GitHub API traffic to fixture files and replays it,
+// so that GraphQL and REST calls can be exercised deterministically offline.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Fixture is the on-disk format of a recording
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request/response pair
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request identifies a recorded request. GraphQL requests are matched on
+// Query and Variables; other requests on Method and URL.
+type Request struct {
+	Method    string          `json:"method"`
+	URL       string          `json:"url"`
+	Query     string          `json:"query,omitempty"`
+	Variables json.RawMessage `json:"variables,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// key returns the string requests are matched on
+func (r Request) key() string {
+	if r.Query != "" {
+		// Fixtures are indented on save, so compare variables in compact form
+		var variables bytes.Buffer
+		if err := json.Compact(&variables, r.Variables); err != nil {
+			variables.Write(r.Variables)
+		}
+		return "graphql " + normalizeQuery(r.Query) + " " + variables.String()
+	}
+	return r.Method + " " + r.URL
+}
+
+// normalizeQuery collapses whitespace so indentation changes don't break fixtures
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// newRequest reads the request body (restoring it for the next transport)
+// and builds its Request description
+func newRequest(req *http.Request) (Request, error) {
+	r := Request{Method: req.Method, URL: req.URL.String()}
+	if req.Body == nil {
+		return r, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return r, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Query == "" {
+		return r, nil
+	}
+
+	// Re-marshal variables so keys are sorted and formatting is canonical
+	variables, err := json.Marshal(payload.Variables)
+	if err != nil {
+		return r, fmt.Errorf("failed to encode variables: %w", err)
+	}
+	r.Query = normalizeQuery(payload.Query)
+	r.Variables = variables
+	return r, nil
+}
+
+// Load reads a fixture file
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture %s: %w", path, err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// Save writes a fixture file
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fixture %s: %w", path, err)
+	}
+	return nil
+}
+
+// Recorder is an http.RoundTripper that forwards requests and appends every
+// request/response pair to a fixture file
+type Recorder struct {
+	mu      sync.Mutex
+	path    string
+	next    http.RoundTripper
+	fixture Fixture
+}
+
+// NewRecorder creates a Recorder writing to path. If next is nil,
+// http.DefaultTransport is used.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, next: next}
+}
+
+// RoundTrip forwards the request and records the response.
+// The fixture is rewritten after every request so a failing run is still captured.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fixture.Interactions = append(r.fixture.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       string(body),
+		},
+	})
+	if err := r.fixture.Save(r.path); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Replayer is an http.RoundTripper that answers requests from a fixture.
+// Identical requests are answered with their recordings in order.
+type Replayer struct {
+	mu      sync.Mutex
+	pending map[string][]Response
+}
+
+// NewReplayer creates a Replayer from a fixture
+func NewReplayer(fixture *Fixture) *Replayer {
+	pending := make(map[string][]Response)
+	for _, interaction := range fixture.Interactions {
+		key := interaction.Request.key()
+		pending[key] = append(pending[key], interaction.Response)
+	}
+	return &Replayer{pending: pending}
+}
+
+// RoundTrip returns the next recorded response matching the request
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := recorded.key()
+	responses := r.pending[key]
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no recorded response for %s", key)
+	}
+	recordedResp := responses[0]
+	r.pending[key] = responses[1:]
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResp.StatusCode, http.StatusText(recordedResp.StatusCode)),
+		StatusCode:    recordedResp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recordedResp.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recordedResp.Body)),
+		ContentLength: int64(len(recordedResp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Remaining returns the number of recorded responses that were not replayed
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, responses := range r.pending {
+		count += len(responses)
+	}
+	return count
+}
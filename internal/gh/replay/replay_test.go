@@ -0,0 +1,254 @@
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc serves requests with a function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+const graphQLURL = "https://api.github.com/graphql"
+
+// graphQLRequest builds a GraphQL request with the given body
+func graphQLRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, graphQLURL, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+// restRequest builds a REST request
+func restRequest(t *testing.T, method, url, body string) *http.Request {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+// readBody returns the body of a response
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestReplayerMatchesRequests(t *testing.T) {
+	issueURL := "https://api.github.com/repos/acme/pm/issues/1"
+	fixture := &Fixture{Interactions: []Interaction{
+		{
+			Request: Request{
+				Method:    http.MethodPost,
+				URL:       graphQLURL,
+				Query:     "query($owner: String!, $number: Int!) { repository(owner: $owner) { issue(number: $number) { id } } }",
+				Variables: json.RawMessage("{\n  \"number\": 1,\n  \"owner\": \"acme\"\n}"),
+			},
+			Response: Response{StatusCode: http.StatusOK, Body: "issue 1"},
+		},
+		{
+			Request:  Request{Method: http.MethodGet, URL: issueURL},
+			Response: Response{StatusCode: http.StatusOK, Body: "get issue"},
+		},
+		{
+			Request:  Request{Method: http.MethodPatch, URL: issueURL},
+			Response: Response{StatusCode: http.StatusOK, Body: "patch issue"},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		request  func(t *testing.T) *http.Request
+		wantBody string // "" if the request must not match
+	}{
+		{
+			name: "GraphQL with other whitespace and variable order",
+			request: func(t *testing.T) *http.Request {
+				return graphQLRequest(t, `{"query":"query($owner: String!, $number: Int!) {\n  repository(owner: $owner) {\n    issue(number: $number) { id }\n  }\n}","variables":{"owner":"acme","number":1}}`)
+			},
+			wantBody: "issue 1",
+		},
+		{
+			name: "GraphQL with other variables",
+			request: func(t *testing.T) *http.Request {
+				return graphQLRequest(t, `{"query":"query($owner: String!, $number: Int!) { repository(owner: $owner) { issue(number: $number) { id } } }","variables":{"owner":"acme","number":2}}`)
+			},
+		},
+		{
+			name: "GraphQL with another query",
+			request: func(t *testing.T) *http.Request {
+				return graphQLRequest(t, `{"query":"query($owner: String!, $number: Int!) { repository(owner: $owner) { issue(number: $number) { title } } }","variables":{"owner":"acme","number":1}}`)
+			},
+		},
+		{
+			name: "REST on method and URL",
+			request: func(t *testing.T) *http.Request {
+				return restRequest(t, http.MethodGet, issueURL, "")
+			},
+			wantBody: "get issue",
+		},
+		{
+			name: "REST ignores the body",
+			request: func(t *testing.T) *http.Request {
+				return restRequest(t, http.MethodPatch, issueURL, `{"state":"closed"}`)
+			},
+			wantBody: "patch issue",
+		},
+		{
+			name: "REST with another method",
+			request: func(t *testing.T) *http.Request {
+				return restRequest(t, http.MethodDelete, issueURL, "")
+			},
+		},
+		{
+			name: "REST with another URL",
+			request: func(t *testing.T) *http.Request {
+				return restRequest(t, http.MethodGet, "https://api.github.com/repos/acme/pm/issues/2", "")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayer := NewReplayer(fixture)
+			resp, err := replayer.RoundTrip(tt.request(t))
+
+			if tt.wantBody == "" {
+				if err == nil {
+					t.Fatalf("request matched %q, want no match", readBody(t, resp))
+				}
+				if !strings.Contains(err.Error(), "no recorded response for") {
+					t.Errorf("error = %v, want a missing recording error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("request did not match: %v", err)
+			}
+			if got := readBody(t, resp); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestReplayerAnswersRepeatedRequestsInOrder(t *testing.T) {
+	url := "https://api.github.com/repos/acme/pm/issues/1"
+	replayer := NewReplayer(&Fixture{Interactions: []Interaction{
+		{Request: Request{Method: http.MethodGet, URL: url}, Response: Response{StatusCode: http.StatusNotFound, Body: "first"}},
+		{Request: Request{Method: http.MethodGet, URL: url}, Response: Response{StatusCode: http.StatusOK, Body: "second"}},
+	}})
+
+	for _, want := range []struct {
+		status int
+		body   string
+	}{
+		{http.StatusNotFound, "first"},
+		{http.StatusOK, "second"},
+	} {
+		resp, err := replayer.RoundTrip(restRequest(t, http.MethodGet, url, ""))
+		if err != nil {
+			t.Fatalf("request did not match: %v", err)
+		}
+		if got := readBody(t, resp); resp.StatusCode != want.status || got != want.body {
+			t.Errorf("got %d %q, want %d %q", resp.StatusCode, got, want.status, want.body)
+		}
+	}
+
+	if remaining := replayer.Remaining(); remaining != 0 {
+		t.Errorf("Remaining() = %d, want 0", remaining)
+	}
+	if _, err := replayer.RoundTrip(restRequest(t, http.MethodGet, url, "")); err == nil {
+		t.Errorf("third request matched, want every recording used up")
+	}
+}
+
+func TestRecorderSavesReplayableFixture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	graphQLBody := `{"query":"query($owner: String!) {\n  organization(login: $owner) { id }\n}","variables":{"owner":"acme"}}`
+
+	var forwarded []string
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		if req.Body != nil {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+		}
+		forwarded = append(forwarded, body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}, "Set-Cookie": []string{"session=secret"}},
+			Body:       io.NopCloser(strings.NewReader(`{"answer":"` + req.Method + `"}`)),
+			Request:    req,
+		}, nil
+	})
+	recorder := NewRecorder(path, next)
+
+	for _, req := range []*http.Request{
+		graphQLRequest(t, graphQLBody),
+		restRequest(t, http.MethodGet, "https://api.github.com/repos/acme/pm", ""),
+	} {
+		resp, err := recorder.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		if got, want := readBody(t, resp), `{"answer":"`+req.Method+`"}`; got != want {
+			t.Errorf("body = %q, want %q", got, want)
+		}
+	}
+	if len(forwarded) != 2 || forwarded[0] != graphQLBody {
+		t.Errorf("forwarded bodies %q, want the GraphQL body intact", forwarded)
+	}
+
+	fixture, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixture.Interactions) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(fixture.Interactions))
+	}
+	recorded := fixture.Interactions[0]
+	if want := "query($owner: String!) { organization(login: $owner) { id } }"; recorded.Request.Query != want {
+		t.Errorf("query = %q, want %q", recorded.Request.Query, want)
+	}
+	if recorded.Response.Header.Get("Set-Cookie") != "" {
+		t.Errorf("Set-Cookie was recorded")
+	}
+	if got := fixture.Interactions[1].Request; got.Method != http.MethodGet || got.Query != "" {
+		t.Errorf("REST request recorded as %+v", got)
+	}
+
+	replayer := NewReplayer(fixture)
+	resp, err := replayer.RoundTrip(graphQLRequest(t, graphQLBody))
+	if err != nil {
+		t.Fatalf("recorded GraphQL request did not replay: %v", err)
+	}
+	if got := readBody(t, resp); got != `{"answer":"POST"}` {
+		t.Errorf("replayed body = %q", got)
+	}
+	if _, err := replayer.RoundTrip(restRequest(t, http.MethodGet, "https://api.github.com/repos/acme/pm", "")); err != nil {
+		t.Errorf("recorded REST request did not replay: %v", err)
+	}
+}
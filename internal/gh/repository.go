@@ -3,62 +3,92 @@ package gh
 import (
 	"context"
 	"fmt"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // ListOrgRepositories lists repositories for an organization using GraphQL
-func ListOrgRepositories(org string) ([]Repository, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
+func (c *APIClient) ListOrgRepositories(org string) ([]Repository, error) {
+	query := `query($owner: String!, $first: Int!, $cursor: String) { organization(login: $owner) { repositories(first: $first, after: $cursor, orderBy: {field: UPDATED_AT, direction: DESC}) { nodes { name description } pageInfo { hasNextPage endCursor } } } }`
 
-	query := `query($owner: String!) { organization(login: $owner) { repositories(first: 100, orderBy: {field: UPDATED_AT, direction: DESC}) { nodes { name description } } } }`
+	return paginate(func(cursor *string) ([]Repository, PageInfo, error) {
+		variables := map[string]interface{}{
+			"owner":  org,
+			"first":  pageSize,
+			"cursor": cursor,
+		}
 
-	variables := map[string]interface{}{
-		"owner": org,
-	}
+		var response struct {
+			Organization struct {
+				Repositories struct {
+					Nodes    []Repository `json:"nodes"`
+					PageInfo PageInfo     `json:"pageInfo"`
+				} `json:"repositories"`
+			} `json:"organization"`
+		}
 
-	var response struct {
-		Organization struct {
-			Repositories struct {
-				Nodes []Repository `json:"nodes"`
-			} `json:"repositories"`
-		} `json:"organization"`
-	}
+		err := c.graphQL.DoWithContext(context.Background(), query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("error listing repositories for org %s: %w", org, err)
+		}
 
-	err = client.DoWithContext(context.Background(), query, variables, &response)
-	if err != nil {
-		return nil, fmt.Errorf("error listing repositories for org %s: %w", org, err)
-	}
-
-	return response.Organization.Repositories.Nodes, nil
+		return response.Organization.Repositories.Nodes, response.Organization.Repositories.PageInfo, nil
+	})
 }
 
 // ListUserRepositories lists repositories for the authenticated user using GraphQL
-func ListUserRepositories() ([]Repository, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
+func (c *APIClient) ListUserRepositories() ([]Repository, error) {
+	query := `query($first: Int!, $cursor: String) { viewer { repositories(first: $first, after: $cursor, orderBy: {field: UPDATED_AT, direction: DESC}) { nodes { name description } pageInfo { hasNextPage endCursor } } } }`
+
+	return paginate(func(cursor *string) ([]Repository, PageInfo, error) {
+		variables := map[string]interface{}{
+			"first":  pageSize,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Viewer struct {
+				Repositories struct {
+					Nodes    []Repository `json:"nodes"`
+					PageInfo PageInfo     `json:"pageInfo"`
+				} `json:"repositories"`
+			} `json:"viewer"`
+		}
+
+		err := c.graphQL.DoWithContext(context.Background(), query, variables, &response)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("error listing user repositories: %w", err)
+		}
+
+		return response.Viewer.Repositories.Nodes, response.Viewer.Repositories.PageInfo, nil
+	})
+}
 
-	query := `query { viewer { repositories(first: 100, orderBy: {field: UPDATED_AT, direction: DESC}) { nodes { name description } } } }`
+// GetRepositoryPermission returns the viewer's permission on a repository
+// (ADMIN, MAINTAIN, WRITE, TRIAGE or READ)
+func (c *APIClient) GetRepositoryPermission(ctx context.Context, owner, repo string) (string, error) {
+	query := `query($owner: String!, $repo: String!) { repository(owner: $owner, name: $repo) { id viewerPermission } }`
+
+	variables := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}
 
 	var response struct {
-		Viewer struct {
-			Repositories struct {
-				Nodes []Repository `json:"nodes"`
-			} `json:"repositories"`
-		} `json:"viewer"`
+		Repository struct {
+			ID               string `json:"id"`
+			ViewerPermission string `json:"viewerPermission"`
+		} `json:"repository"`
 	}
 
-	err = client.DoWithContext(context.Background(), query, nil, &response)
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
 	if err != nil {
-		return nil, fmt.Errorf("error listing user repositories: %w", err)
+		return "", fmt.Errorf("failed to query repository permission: %w", err)
+	}
+
+	if response.Repository.ID == "" {
+		return "", newError(ErrRepoNotFound, "repository %s/%s not found", owner, repo)
 	}
 
-	return response.Viewer.Repositories.Nodes, nil
+	return response.Repository.ViewerPermission, nil
 }
 
 // GetRepositoryNames returns just the names of repositories as a slice
@@ -3,25 +3,18 @@ package gh
 import (
 	"context"
 	"fmt"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // AddSubIssue adds a child issue to a parent issue using GitHub's sub-issues GraphQL API
 // Requires the GraphQL-Features: sub_issues header
-func AddSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) AddSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
 	// Get issue node IDs for both issues
-	parentNodeID, err := GetIssueNodeID(ctx, *client, owner, repo, parentNumber)
+	parentNodeID, err := c.GetIssueNodeID(ctx, owner, repo, parentNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get parent issue node ID: %w", err)
 	}
 
-	childNodeID, err := GetIssueNodeID(ctx, *client, owner, repo, childNumber)
+	childNodeID, err := c.GetIssueNodeID(ctx, owner, repo, childNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get child issue node ID: %w", err)
 	}
@@ -68,7 +61,7 @@ func AddSubIssue(ctx context.Context, owner, repo string, parentNumber, childNum
 	}
 
 	// Execute mutation with required header
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err = c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return fmt.Errorf("failed to add sub-issue relationship: %w", err)
 	}
@@ -77,19 +70,14 @@ func AddSubIssue(ctx context.Context, owner, repo string, parentNumber, childNum
 }
 
 // RemoveSubIssue removes a child issue from a parent issue using GitHub's sub-issues GraphQL API
-func RemoveSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) RemoveSubIssue(ctx context.Context, owner, repo string, parentNumber, childNumber int) error {
 	// Get issue node IDs for both issues
-	parentNodeID, err := GetIssueNodeID(ctx, *client, owner, repo, parentNumber)
+	parentNodeID, err := c.GetIssueNodeID(ctx, owner, repo, parentNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get parent issue node ID: %w", err)
 	}
 
-	childNodeID, err := GetIssueNodeID(ctx, *client, owner, repo, childNumber)
+	childNodeID, err := c.GetIssueNodeID(ctx, owner, repo, childNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get child issue node ID: %w", err)
 	}
@@ -136,7 +124,7 @@ func RemoveSubIssue(ctx context.Context, owner, repo string, parentNumber, child
 	}
 
 	// Execute mutation with required header
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err = c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return fmt.Errorf("failed to remove sub-issue relationship: %w", err)
 	}
@@ -6,16 +6,10 @@ import (
 	"fmt"
 
 	"github.com/Zytera/gh-project-management/internal/templates"
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // GetTemplateFromRepo fetches a template file from the repository
-func GetTemplateFromRepo(ctx context.Context, owner, repo, issueType string) (*templates.IssueTemplate, string, error) {
-	client, err := api.DefaultRESTClient()
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create REST client: %w", err)
-	}
-
+func (c *APIClient) GetTemplateFromRepo(ctx context.Context, owner, repo, issueType string) (*templates.IssueTemplate, string, error) {
 	// Get template file name
 	templateFile := templates.GetTemplateFileName(issueType)
 	path := fmt.Sprintf(".github/ISSUE_TEMPLATE/%s", templateFile)
@@ -26,7 +20,7 @@ func GetTemplateFromRepo(ctx context.Context, owner, repo, issueType string) (*t
 		SHA     string `json:"sha"`
 	}
 
-	err = client.Get(fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path), &response)
+	err := c.rest.Get(fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path), &response)
 	if err != nil {
 		// File doesn't exist, return nil (will use default template)
 		return nil, "", nil
@@ -4,17 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
-
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 // TransferIssue transfers an issue to a different repository using GitHub's GraphQL API
-func TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo, sourceRepo string) (int, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return 0, fmt.Errorf("failed to create GraphQL client: %w", err)
-	}
-
+func (c *APIClient) TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo, sourceRepo string) (int, error) {
 	// Parse source repo (format: "owner/repo")
 	parts := strings.Split(sourceRepo, "/")
 	if len(parts) != 2 {
@@ -24,13 +17,13 @@ func TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo
 	sourceRepoName := parts[1]
 
 	// Get issue node ID (using existing function from dependencies.go)
-	issueNodeID, err := GetIssueNodeID(ctx, *client, sourceOwner, sourceRepoName, issueNumber)
+	issueNodeID, err := c.GetIssueNodeID(ctx, sourceOwner, sourceRepoName, issueNumber)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get issue node ID: %w", err)
 	}
 
 	// Get target repository node ID
-	repoNodeID, err := getRepositoryNodeID(ctx, *client, targetOwner, targetRepo)
+	repoNodeID, err := c.GetRepositoryNodeID(ctx, targetOwner, targetRepo)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get repository node ID: %w", err)
 	}
@@ -64,7 +57,7 @@ func TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo
 		} `json:"transferIssue"`
 	}
 
-	err = client.DoWithContext(ctx, mutation, variables, &response)
+	err = c.graphQL.DoWithContext(ctx, mutation, variables, &response)
 	if err != nil {
 		return 0, fmt.Errorf("failed to transfer issue: %w", err)
 	}
@@ -72,8 +65,8 @@ func TransferIssue(ctx context.Context, issueNumber int, targetOwner, targetRepo
 	return response.TransferIssue.Issue.Number, nil
 }
 
-// getRepositoryNodeID retrieves the GraphQL node ID for a repository
-func getRepositoryNodeID(ctx context.Context, client api.GraphQLClient, owner, repo string) (string, error) {
+// GetRepositoryNodeID retrieves the GraphQL node ID for a repository
+func (c *APIClient) GetRepositoryNodeID(ctx context.Context, owner, repo string) (string, error) {
 	query := `
 		query($owner: String!, $repo: String!) {
 			repository(owner: $owner, name: $repo) {
@@ -93,13 +86,13 @@ func getRepositoryNodeID(ctx context.Context, client api.GraphQLClient, owner, r
 		} `json:"repository"`
 	}
 
-	err := client.DoWithContext(ctx, query, variables, &response)
+	err := c.graphQL.DoWithContext(ctx, query, variables, &response)
 	if err != nil {
 		return "", fmt.Errorf("failed to query repository: %w", err)
 	}
 
 	if response.Repository.ID == "" {
-		return "", fmt.Errorf("repository %s/%s not found", owner, repo)
+		return "", newError(ErrRepoNotFound, "repository %s/%s not found", owner, repo)
 	}
 
 	return response.Repository.ID, nil
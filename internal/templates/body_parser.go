@@ -0,0 +1,157 @@
+package templates
+
+import (
+	"strings"
+	"unicode"
+)
+
+// noResponse is what GitHub issue forms write for optional fields left empty
+const noResponse = "_No response_"
+
+// Section is a "### <label>" section of an issue body
+type Section struct {
+	Label string
+	Value string
+}
+
+// ParsedBody is an issue body read back into template field IDs
+type ParsedBody struct {
+	Fields   map[string]string // Field ID -> value, for sections with a value
+	Unknown  []Section         // Sections whose label matches no template field, or repeat one
+	Missing  []string          // IDs of required fields without a value
+	Preamble string            // Text before the first section
+}
+
+// ParseBody maps the "### <label>" sections written by BuildBodyFromTemplate
+// (or by GitHub issue forms) back to the template's field IDs. Labels match
+// exactly first, then ignoring case and surrounding space, then by their words
+// alone (so "Description" matches "📝 Description") or by field ID. Headings
+// inside fenced code blocks are part of the value, and "_No response_" counts
+// as empty. Checkboxes sections are read as the comma-separated labels of the
+// checked items.
+func ParseBody(template *IssueTemplate, body string) *ParsedBody {
+	parsed := &ParsedBody{Fields: make(map[string]string)}
+
+	preamble, sections := splitSections(body)
+	parsed.Preamble = preamble
+
+	seen := make(map[string]bool)
+	for _, section := range sections {
+		field := template.fieldForLabel(section.Label)
+		if field == nil || seen[field.ID] {
+			parsed.Unknown = append(parsed.Unknown, section)
+			continue
+		}
+		seen[field.ID] = true
+
+		value := section.Value
+		if field.Type == FieldTypeCheckboxes {
+			value = parseCheckboxes(value)
+		}
+		if value != "" && value != noResponse {
+			parsed.Fields[field.ID] = value
+		}
+	}
+
+	for _, field := range template.GetRequiredFields() {
+		if _, ok := parsed.Fields[field.ID]; !ok {
+			parsed.Missing = append(parsed.Missing, field.ID)
+		}
+	}
+
+	return parsed
+}
+
+// fieldForLabel finds the input field rendered with a section label
+func (t *IssueTemplate) fieldForLabel(label string) *BodyField {
+	inputs := t.GetAllInputFields()
+
+	for i, field := range inputs {
+		if sectionLabel(field) == label {
+			return &inputs[i]
+		}
+	}
+	for i, field := range inputs {
+		if strings.EqualFold(strings.TrimSpace(sectionLabel(field)), strings.TrimSpace(label)) {
+			return &inputs[i]
+		}
+	}
+	// Hand-edited headings: ignore emoji and punctuation, or use the field ID
+	key := normalizeLabel(label)
+	for i, field := range inputs {
+		if key != "" && (normalizeLabel(sectionLabel(field)) == key || normalizeLabel(field.ID) == key) {
+			return &inputs[i]
+		}
+	}
+	return nil
+}
+
+// normalizeLabel lowercases a label and keeps only its words
+func normalizeLabel(label string) string {
+	words := strings.FieldsFunc(strings.ToLower(label), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// sectionLabel is the heading BuildBodyFromTemplate writes for a field
+func sectionLabel(field BodyField) string {
+	if field.Attributes.Label == "" {
+		return field.ID
+	}
+	return field.Attributes.Label
+}
+
+// splitSections splits a body at "### " headings outside fenced code blocks.
+// Values are trimmed of surrounding blank lines and trailing space.
+func splitSections(body string) (string, []Section) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	var preamble []string
+	var sections []Section
+	var current *Section
+	var value []string
+	inFence := false
+
+	flush := func() {
+		if current != nil {
+			current.Value = trimBlankLines(value)
+			sections = append(sections, *current)
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+
+		if !inFence && strings.HasPrefix(line, "### ") {
+			flush()
+			current = &Section{Label: strings.TrimSpace(strings.TrimPrefix(line, "### "))}
+			value = nil
+			continue
+		}
+
+		if current == nil {
+			preamble = append(preamble, line)
+		} else {
+			value = append(value, line)
+		}
+	}
+	flush()
+
+	return trimBlankLines(preamble), sections
+}
+
+// trimBlankLines joins lines, dropping blank lines at both ends
+func trimBlankLines(lines []string) string {
+	start, end := 0, len(lines)
+	for start < end && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return strings.TrimRight(strings.Join(lines[start:end], "\n"), " \t")
+}
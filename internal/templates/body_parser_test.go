@@ -0,0 +1,238 @@
+package templates
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testTemplateYAML has a field of every type, a required checkbox, a
+// multiple dropdown, a dropdown with a default and extended validations
+const testTemplateYAML = `
+name: Task
+description: A piece of work
+title: "[Task] "
+body:
+  - type: markdown
+    attributes:
+      value: Describe the work.
+  - type: textarea
+    id: description
+    attributes:
+      label: 📝 Description
+    validations:
+      required: true
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    x-gh-project-management:
+      validations:
+        pattern: '^v\d+\.\d+\.\d+$'
+        min_length: 6
+  - type: dropdown
+    id: platforms
+    attributes:
+      label: Platforms
+      multiple: true
+      options: [iOS, Android, Web]
+  - type: dropdown
+    id: size
+    attributes:
+      label: Size
+      options: [S, M, L]
+      default: 1
+  - type: checkboxes
+    id: checks
+    attributes:
+      label: Checks
+      options:
+        - label: Tests added
+          required: true
+        - label: Docs updated
+  - type: textarea
+    id: notes
+    attributes:
+      label: Notes
+`
+
+// testTemplate parses testTemplateYAML
+func testTemplate(t *testing.T) *IssueTemplate {
+	t.Helper()
+	template, err := ParseTemplate([]byte(testTemplateYAML))
+	if err != nil {
+		t.Fatalf("ParseTemplate: %v", err)
+	}
+	return template
+}
+
+func TestParseBodyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]string
+		crlf   bool              // Parse the body with Windows line endings
+		want   map[string]string // Fields read back, if not the same as fields
+	}{
+		{
+			name:   "required fields only",
+			fields: map[string]string{"description": "Add a login endpoint", "checks": "Tests added"},
+			want:   map[string]string{"description": "Add a login endpoint", "checks": "Tests added", "size": "M"},
+		},
+		{
+			name: "every field",
+			fields: map[string]string{
+				"description": "Add a login endpoint\n\nIt returns a token.",
+				"version":     "v1.2.0",
+				"platforms":   "iOS,Web",
+				"size":        "L",
+				"checks":      "tests added, Docs updated",
+				"notes":       "None",
+			},
+			want: map[string]string{
+				"description": "Add a login endpoint\n\nIt returns a token.",
+				"version":     "v1.2.0",
+				"platforms":   "iOS, Web",
+				"size":        "L",
+				"checks":      "Tests added, Docs updated",
+				"notes":       "None",
+			},
+		},
+		{
+			name: "heading inside fenced code",
+			fields: map[string]string{
+				"description": "Run this:\n\n```sh\n### Not a section\necho ok\n```",
+				"size":        "S",
+				"checks":      "Tests added",
+				"notes":       "~~~md\n### Checks\n- [x] Tests added\n~~~",
+			},
+		},
+		{
+			name:   "CRLF line endings",
+			fields: map[string]string{"description": "First line\nSecond line", "size": "S", "checks": "Tests added", "notes": "Done"},
+			crlf:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := testTemplate(t)
+			body, err := BuildBodyFromTemplate(template, tt.fields)
+			if err != nil {
+				t.Fatalf("BuildBodyFromTemplate: %v", err)
+			}
+			if tt.crlf {
+				body = strings.ReplaceAll(body, "\n", "\r\n")
+			}
+
+			parsed := ParseBody(template, body)
+			want := tt.want
+			if want == nil {
+				want = tt.fields
+			}
+			if !maps.Equal(parsed.Fields, want) {
+				t.Errorf("Fields = %q, want %q\nbody:\n%s", parsed.Fields, want, body)
+			}
+			if len(parsed.Unknown) > 0 || len(parsed.Missing) > 0 || parsed.Preamble != "" {
+				t.Errorf("Unknown = %q, Missing = %q, Preamble = %q, want none", parsed.Unknown, parsed.Missing, parsed.Preamble)
+			}
+		})
+	}
+}
+
+func TestParseBody(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantFields   map[string]string
+		wantUnknown  []Section
+		wantMissing  []string
+		wantPreamble string
+	}{
+		{
+			name:        "unknown sections are kept",
+			body:        "### 📝 Description\n\nAdd it\n\n### Checks\n\n- [x] Tests added\n\n### Rollout\n\nBehind a flag\n",
+			wantFields:  map[string]string{"description": "Add it", "checks": "Tests added"},
+			wantUnknown: []Section{{Label: "Rollout", Value: "Behind a flag"}},
+		},
+		{
+			name:        "repeated sections are unknown",
+			body:        "### 📝 Description\n\nFirst\n\n### 📝 Description\n\nSecond\n\n### Checks\n\n- [x] Tests added",
+			wantFields:  map[string]string{"description": "First", "checks": "Tests added"},
+			wantUnknown: []Section{{Label: "📝 Description", Value: "Second"}},
+		},
+		{
+			name:        "missing required fields",
+			body:        "### Notes\n\nLater\n\n### Checks\n\n- [ ] Tests added\n- [ ] Docs updated",
+			wantFields:  map[string]string{"notes": "Later"},
+			wantMissing: []string{"description", "checks"},
+		},
+		{
+			name:        "no response counts as empty",
+			body:        "### 📝 Description\n\n_No response_\n\n### Checks\n\n- [X] Tests added",
+			wantFields:  map[string]string{"checks": "Tests added"},
+			wantMissing: []string{"description"},
+		},
+		{
+			name:       "hand-edited headings",
+			body:       "### description\n\nBy case\n\n### ✅ Checks:\n\n* [x] Tests added\n\n### NOTES\n\nBy case too",
+			wantFields: map[string]string{"description": "By case", "checks": "Tests added", "notes": "By case too"},
+		},
+		{
+			name:         "preamble before the first section",
+			body:         "Part of the login epic.\n\n### 📝 Description\n\nAdd it\n\n### Checks\n\n- [x] Tests added",
+			wantFields:   map[string]string{"description": "Add it", "checks": "Tests added"},
+			wantPreamble: "Part of the login epic.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := ParseBody(testTemplate(t), tt.body)
+			if !maps.Equal(parsed.Fields, tt.wantFields) {
+				t.Errorf("Fields = %q, want %q", parsed.Fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(parsed.Unknown, tt.wantUnknown) {
+				t.Errorf("Unknown = %q, want %q", parsed.Unknown, tt.wantUnknown)
+			}
+			if !slices.Equal(parsed.Missing, tt.wantMissing) {
+				t.Errorf("Missing = %q, want %q", parsed.Missing, tt.wantMissing)
+			}
+			if parsed.Preamble != tt.wantPreamble {
+				t.Errorf("Preamble = %q, want %q", parsed.Preamble, tt.wantPreamble)
+			}
+		})
+	}
+}
+
+func TestUpdateBody(t *testing.T) {
+	body := "Part of the login epic.\n\n### 📝 Description\n\nAdd it\n\n### Notes\n\nLater\n\n### Checks\n\n- [x] Tests added\n\n### Rollout\n\nBehind a flag\n"
+
+	tests := []struct {
+		name    string
+		changes map[string]string
+		want    string
+	}{
+		{
+			name:    "no changes",
+			changes: nil,
+			want:    "Part of the login epic.\n\n### 📝 Description\n\nAdd it\n\n### Checks\n\n- [x] Tests added\n- [ ] Docs updated\n\n### Notes\n\nLater\n\n### Rollout\n\nBehind a flag\n\n",
+		},
+		{
+			name:    "change and remove fields",
+			changes: map[string]string{"description": "Add it with tests", "notes": "", "size": "L"},
+			want:    "Part of the login epic.\n\n### 📝 Description\n\nAdd it with tests\n\n### Size\n\nL\n\n### Checks\n\n- [x] Tests added\n- [ ] Docs updated\n\n### Rollout\n\nBehind a flag\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := testTemplate(t)
+			got := UpdateBody(template, ParseBody(template, body), tt.changes)
+			if got != tt.want {
+				t.Errorf("UpdateBody =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
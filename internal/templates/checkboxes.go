@@ -0,0 +1,155 @@
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// taskListItem matches a line of a markdown task list, like "- [x] Option"
+var taskListItem = regexp.MustCompile(`^\s*[-*]\s+\[([ xX])\]\s+(.*?)\s*$`)
+
+// UnmarshalYAML reads the options of a field: strings for dropdowns, and
+// objects with a label and a required flag for checkboxes, as in GitHub issue
+// forms. Options gets the labels of both; Checkboxes gets the checkbox options.
+func (a *FieldAttributes) UnmarshalYAML(node *yaml.Node) error {
+	type plain FieldAttributes
+	var raw struct {
+		plain   `yaml:",inline"`
+		Options []yaml.Node `yaml:"options"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	*a = FieldAttributes(raw.plain)
+	for _, option := range raw.Options {
+		switch option.Kind {
+		case yaml.ScalarNode:
+			a.Options = append(a.Options, option.Value)
+		case yaml.MappingNode:
+			var checkbox CheckboxOption
+			if err := option.Decode(&checkbox); err != nil {
+				return err
+			}
+			if checkbox.Label == "" {
+				return fmt.Errorf("line %d: checkbox option has no label", option.Line)
+			}
+			a.Options = append(a.Options, checkbox.Label)
+			a.Checkboxes = append(a.Checkboxes, checkbox)
+		default:
+			return fmt.Errorf("line %d: option must be a string or a checkbox with a label", option.Line)
+		}
+	}
+	return nil
+}
+
+// CheckboxOptions returns the options of a checkboxes field. Options given
+// only as labels in Options are optional.
+func (f *BodyField) CheckboxOptions() []CheckboxOption {
+	if len(f.Attributes.Checkboxes) > 0 {
+		return f.Attributes.Checkboxes
+	}
+	options := make([]CheckboxOption, len(f.Attributes.Options))
+	for i, label := range f.Attributes.Options {
+		options[i] = CheckboxOption{Label: label}
+	}
+	return options
+}
+
+// IsRequired reports whether a field needs a value: it is marked required,
+// or it is a checkboxes field with an option that must be checked
+func (f *BodyField) IsRequired() bool {
+	if f.Validations.Required {
+		return true
+	}
+	if f.Type == FieldTypeCheckboxes {
+		for _, option := range f.CheckboxOptions() {
+			if option.Required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SplitValues splits the value of a field with several values, like
+// "opt1, opt2", trimming each one and dropping empty ones
+func SplitValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// checkboxesViolations checks that the values of a checkboxes field name
+// its options and that the required options are checked
+func (f *BodyField) checkboxesViolations(value string) []string {
+	var violations []string
+	checked, unknown := f.checkedOptions(value)
+	if len(unknown) > 0 {
+		violations = append(violations, fmt.Sprintf("invalid value for field %s: '%s' is not an option (options: %s)", f.ID, strings.Join(unknown, "', '"), strings.Join(f.Attributes.Options, ", ")))
+	}
+	for _, option := range f.CheckboxOptions() {
+		if option.Required && !checked[option.Label] {
+			violations = append(violations, fmt.Sprintf("field %s: option '%s' must be checked", f.ID, option.Label))
+		}
+	}
+	return violations
+}
+
+// checkedOptions maps the values of a checkboxes field to the labels of its
+// options, ignoring case. Values that match no option are returned in unknown.
+func (f *BodyField) checkedOptions(value string) (map[string]bool, []string) {
+	checked := make(map[string]bool)
+	var unknown []string
+	for _, v := range SplitValues(value) {
+		found := false
+		for _, option := range f.CheckboxOptions() {
+			if strings.EqualFold(option.Label, v) {
+				checked[option.Label] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, v)
+		}
+	}
+	return checked, unknown
+}
+
+// renderCheckboxes renders the options of a checkboxes field as a task list,
+// checking the ones named in value
+func renderCheckboxes(field BodyField, value string) string {
+	checked, _ := field.checkedOptions(value)
+
+	lines := make([]string, 0, len(field.Attributes.Options))
+	for _, option := range field.CheckboxOptions() {
+		box := "[ ]"
+		if checked[option.Label] {
+			box = "[x]"
+		}
+		lines = append(lines, fmt.Sprintf("- %s %s", box, option.Label))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseCheckboxes reads a task list written by renderCheckboxes, or by
+// GitHub issue forms, back into the comma-separated labels of the checked
+// items. Other lines are ignored.
+func parseCheckboxes(list string) string {
+	var checked []string
+	for _, line := range strings.Split(list, "\n") {
+		match := taskListItem.FindStringSubmatch(line)
+		if match != nil && match[1] != " " && match[2] != "" {
+			checked = append(checked, match[2])
+		}
+	}
+	return strings.Join(checked, ", ")
+}
@@ -0,0 +1,60 @@
+package templates
+
+import "testing"
+
+func TestParseCheckboxes(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		want string
+	}{
+		{name: "none checked", list: "- [ ] Tests added\n- [ ] Docs updated", want: ""},
+		{name: "checked in order", list: "- [x] Tests added\n- [ ] Docs updated\n- [X] Changelog", want: "Tests added, Changelog"},
+		{name: "asterisk bullets and indentation", list: "  * [x] Tests added  \n\t- [x]   Docs updated", want: "Tests added, Docs updated"},
+		{name: "other lines are ignored", list: "Before you merge:\n- [x] Tests added\n- not a task\n[x] no bullet", want: "Tests added"},
+		{name: "item without label", list: "- [x] \n- [x] Docs updated", want: "Docs updated"},
+		{name: "empty", list: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCheckboxes(tt.list); got != tt.want {
+				t.Errorf("parseCheckboxes(%q) = %q, want %q", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderCheckboxes(t *testing.T) {
+	field := BodyField{
+		Type: FieldTypeCheckboxes,
+		ID:   "checks",
+		Attributes: FieldAttributes{
+			Options:    []string{"Tests added", "Docs updated"},
+			Checkboxes: []CheckboxOption{{Label: "Tests added", Required: true}, {Label: "Docs updated"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "nothing checked", value: "", want: "- [ ] Tests added\n- [ ] Docs updated"},
+		{name: "one checked", value: "Docs updated", want: "- [ ] Tests added\n- [x] Docs updated"},
+		{name: "labels ignore case and spacing", value: " docs UPDATED ,tests added", want: "- [x] Tests added\n- [x] Docs updated"},
+		{name: "unknown labels are dropped", value: "Tests added, Benchmarks", want: "- [x] Tests added\n- [ ] Docs updated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderCheckboxes(field, tt.value)
+			if got != tt.want {
+				t.Errorf("renderCheckboxes(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+			if want := parseCheckboxes(tt.want); parseCheckboxes(got) != want {
+				t.Errorf("parseCheckboxes(renderCheckboxes(%q)) = %q, want %q", tt.value, parseCheckboxes(got), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package templates
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultOption returns the option a dropdown field preselects, given by
+// the index in its default attribute, or "" if it has none
+func (f *BodyField) DefaultOption() string {
+	if f.Type != FieldTypeDropdown || f.Attributes.Default == nil {
+		return ""
+	}
+	index := *f.Attributes.Default
+	if index < 0 || index >= len(f.Attributes.Options) {
+		return ""
+	}
+	return f.Attributes.Options[index]
+}
+
+// WithDefaults returns a copy of fields with the default option of every
+// dropdown field that has no value, as GitHub issue forms preselect it
+func (t *IssueTemplate) WithDefaults(fields map[string]string) map[string]string {
+	withDefaults := make(map[string]string, len(fields))
+	for id, value := range fields {
+		withDefaults[id] = value
+	}
+	for _, field := range t.GetAllInputFields() {
+		if withDefaults[field.ID] != "" {
+			continue
+		}
+		if option := field.DefaultOption(); option != "" {
+			withDefaults[field.ID] = option
+		}
+	}
+	return withDefaults
+}
+
+// dropdownViolations checks that the value of a dropdown field is one of
+// its options. A multiple dropdown takes several comma-separated options.
+func (f *BodyField) dropdownViolations(value string) []string {
+	if value == "" || len(f.Attributes.Options) == 0 {
+		return nil
+	}
+
+	if !f.Attributes.Multiple {
+		if !slices.Contains(f.Attributes.Options, value) {
+			return []string{fmt.Sprintf("invalid value for field %s: must be one of %v", f.ID, f.Attributes.Options)}
+		}
+		return nil
+	}
+
+	var unknown []string
+	for _, v := range SplitValues(value) {
+		if !slices.Contains(f.Attributes.Options, v) {
+			unknown = append(unknown, v)
+		}
+	}
+	if len(unknown) > 0 {
+		return []string{fmt.Sprintf("invalid value for field %s: '%s' is not an option (options: %s)", f.ID, strings.Join(unknown, "', '"), strings.Join(f.Attributes.Options, ", "))}
+	}
+	return nil
+}
+
+// renderDropdown renders the value of a dropdown field. The options of a
+// multiple dropdown are joined with ", ", as GitHub issue forms write them.
+func renderDropdown(field BodyField, value string) string {
+	if !field.Attributes.Multiple {
+		return value
+	}
+	return strings.Join(SplitValues(value), ", ")
+}
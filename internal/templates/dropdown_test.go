@@ -0,0 +1,114 @@
+package templates
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestDropdownValues(t *testing.T) {
+	tests := []struct {
+		name           string
+		field          string
+		value          string
+		wantRendered   string
+		wantViolations []string
+	}{
+		{name: "single option", field: "size", value: "L", wantRendered: "L"},
+		{name: "single unknown option", field: "size", value: "XL", wantViolations: []string{"invalid value for field size: must be one of [S M L]"}},
+		{name: "single with several options", field: "size", value: "S, M", wantViolations: []string{"invalid value for field size: must be one of [S M L]"}},
+		{name: "multiple options", field: "platforms", value: "iOS,Web", wantRendered: "iOS, Web"},
+		{name: "multiple with empty values", field: "platforms", value: " Android , ,Web,", wantRendered: "Android, Web"},
+		{name: "multiple unknown options", field: "platforms", value: "iOS, Windows, Linux", wantViolations: []string{"invalid value for field platforms: 'Windows', 'Linux' is not an option (options: iOS, Android, Web)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var field BodyField
+			for _, f := range testTemplate(t).GetAllInputFields() {
+				if f.ID == tt.field {
+					field = f
+				}
+			}
+
+			violations := Violations(field.ValidateFieldValue(tt.value))
+			if !slices.Equal(violations, tt.wantViolations) {
+				t.Errorf("violations = %q, want %q", violations, tt.wantViolations)
+			}
+			if tt.wantViolations == nil {
+				if got := renderDropdown(field, tt.value); got != tt.wantRendered {
+					t.Errorf("renderDropdown(%q) = %q, want %q", tt.value, got, tt.wantRendered)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultOption(t *testing.T) {
+	index := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		field   BodyField
+		want    string
+		wantSet bool // WithDefaults fills the field
+	}{
+		{
+			name:    "index of an option",
+			field:   BodyField{Type: FieldTypeDropdown, ID: "size", Attributes: FieldAttributes{Options: []string{"S", "M", "L"}, Default: index(2)}},
+			want:    "L",
+			wantSet: true,
+		},
+		{
+			name:  "no default",
+			field: BodyField{Type: FieldTypeDropdown, ID: "size", Attributes: FieldAttributes{Options: []string{"S", "M", "L"}}},
+		},
+		{
+			name:  "index out of range",
+			field: BodyField{Type: FieldTypeDropdown, ID: "size", Attributes: FieldAttributes{Options: []string{"S", "M", "L"}, Default: index(3)}},
+		},
+		{
+			name:  "negative index",
+			field: BodyField{Type: FieldTypeDropdown, ID: "size", Attributes: FieldAttributes{Options: []string{"S", "M", "L"}, Default: index(-1)}},
+		},
+		{
+			name:  "not a dropdown",
+			field: BodyField{Type: FieldTypeCheckboxes, ID: "size", Attributes: FieldAttributes{Options: []string{"S", "M", "L"}, Default: index(0)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.DefaultOption(); got != tt.want {
+				t.Errorf("DefaultOption() = %q, want %q", got, tt.want)
+			}
+
+			template := &IssueTemplate{Body: []BodyField{tt.field}}
+			want := map[string]string{}
+			if tt.wantSet {
+				want["size"] = tt.want
+			}
+			if got := template.WithDefaults(nil); !maps.Equal(got, want) {
+				t.Errorf("WithDefaults(nil) = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWithDefaultsKeepsValues(t *testing.T) {
+	template := testTemplate(t)
+	fields := map[string]string{"size": "S", "notes": "Later"}
+
+	got := template.WithDefaults(fields)
+	if want := (map[string]string{"size": "S", "notes": "Later"}); !maps.Equal(got, want) {
+		t.Errorf("WithDefaults = %q, want %q", got, want)
+	}
+
+	got = template.WithDefaults(map[string]string{"notes": "Later"})
+	if want := (map[string]string{"size": "M", "notes": "Later"}); !maps.Equal(got, want) {
+		t.Errorf("WithDefaults = %q, want %q", got, want)
+	}
+	if _, ok := fields["platforms"]; ok {
+		t.Errorf("WithDefaults changed its argument")
+	}
+}
@@ -11,65 +11,91 @@ type FieldValue struct {
 	Value string
 }
 
-// BuildBodyFromTemplate builds an issue body dynamically from a template and field values
+// BuildBodyFromTemplate builds an issue body dynamically from a template and
+// field values, after validating them with ValidateFields. Dropdown fields
+// without a value get their default option.
 func BuildBodyFromTemplate(template *IssueTemplate, fields map[string]string) (string, error) {
-	var builder strings.Builder
-
-	for _, field := range template.Body {
-		// Skip markdown fields (they're just informational text)
-		if field.Type == FieldTypeMarkdown {
-			continue
-		}
-
-		// Get field value
-		value, hasValue := fields[field.ID]
+	fields = template.WithDefaults(fields)
+	if err := ValidateFields(template, fields); err != nil {
+		return "", err
+	}
 
-		// Validate required fields
-		if field.Validations.Required && !hasValue {
-			return "", fmt.Errorf("required field '%s' is missing", field.ID)
-		}
+	return renderBody(template, fields), nil
+}
 
-		// Validate field value if present
-		if hasValue && value != "" {
-			if err := field.ValidateFieldValue(value); err != nil {
-				return "", err
-			}
-		}
+// renderBody writes a "### <label>" section for every input field with a value
+func renderBody(template *IssueTemplate, fields map[string]string) string {
+	var builder strings.Builder
 
+	for _, field := range template.GetAllInputFields() {
 		// Only include fields that have values
-		if !hasValue || value == "" {
+		value := fields[field.ID]
+		if value == "" {
 			continue
 		}
 
-		// Build field section
-		label := field.Attributes.Label
-		if label == "" {
-			label = field.ID
+		switch field.Type {
+		case FieldTypeCheckboxes:
+			value = renderCheckboxes(field, value)
+		case FieldTypeDropdown:
+			value = renderDropdown(field, value)
 		}
 
-		builder.WriteString(fmt.Sprintf("### %s\n\n", label))
+		builder.WriteString(fmt.Sprintf("### %s\n\n", sectionLabel(field)))
 		builder.WriteString(value)
 		builder.WriteString("\n\n")
 	}
 
-	return builder.String(), nil
+	return builder.String()
 }
 
-// ValidateFields validates that all required fields are present
-func ValidateFields(template *IssueTemplate, fields map[string]string) error {
-	requiredFields := template.GetRequiredFields()
+// UpdateBody renders a parsed body with changed field values. A change to ""
+// removes the field's section. The preamble and unknown sections are kept,
+// so editing never drops text the template doesn't know about.
+func UpdateBody(template *IssueTemplate, parsed *ParsedBody, changes map[string]string) string {
+	fields := make(map[string]string, len(parsed.Fields)+len(changes))
+	for id, value := range parsed.Fields {
+		fields[id] = value
+	}
+	for id, value := range changes {
+		fields[id] = value
+	}
 
-	for _, field := range requiredFields {
-		value, exists := fields[field.ID]
-		if !exists || value == "" {
-			return fmt.Errorf("required field '%s' (%s) is missing", field.ID, field.Attributes.Label)
+	var builder strings.Builder
+	if parsed.Preamble != "" {
+		builder.WriteString(parsed.Preamble)
+		builder.WriteString("\n\n")
+	}
+	builder.WriteString(renderBody(template, fields))
+	for _, section := range parsed.Unknown {
+		builder.WriteString(fmt.Sprintf("### %s\n\n", section.Label))
+		if section.Value != "" {
+			builder.WriteString(section.Value)
+			builder.WriteString("\n\n")
 		}
+	}
+
+	return builder.String()
+}
+
+// ValidateFields validates that all required fields are present, counting
+// the default option of dropdown fields, and that every value follows the
+// field's validations. All violations are reported in a ValidationError.
+func ValidateFields(template *IssueTemplate, fields map[string]string) error {
+	fields = template.WithDefaults(fields)
 
-		// Validate field value
-		if err := field.ValidateFieldValue(value); err != nil {
-			return err
+	var violations []string
+	for _, field := range template.GetAllInputFields() {
+		value := fields[field.ID]
+		if value == "" {
+			if field.IsRequired() {
+				violations = append(violations, fmt.Sprintf("required field '%s' (%s) is missing", field.ID, field.Attributes.Label))
+			}
+			continue
 		}
+
+		violations = append(violations, Violations(field.ValidateFieldValue(value))...)
 	}
 
-	return nil
+	return validationError(violations)
 }
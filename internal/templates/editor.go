@@ -0,0 +1,163 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+)
+
+// editorHelp heads the buffer written by RenderEditorBuffer
+const editorHelp = `<!--
+Fill in the sections below, then save and close the editor.
+Text in HTML comments like this one is ignored, and empty sections are skipped.
+Keep the "### " headings: they map each section to its template field.
+-->`
+
+// RenderEditorBuffer renders a template as a markdown buffer to fill in with
+// an editor: a "### <label>" section per input field, holding its value in
+// parsed if there is one. Descriptions, placeholders, dropdown options and
+// markdown fields are written as HTML comments, which ParseEditorBuffer drops.
+// The preamble and unknown sections of parsed are kept, so reopening an edited
+// buffer loses nothing, and problems, if any, are listed at the top.
+func RenderEditorBuffer(template *IssueTemplate, parsed *ParsedBody, problems []string) string {
+	var builder strings.Builder
+
+	if len(problems) > 0 {
+		builder.WriteString("<!--\nFix these problems, then save and close the editor again:\n")
+		for _, problem := range problems {
+			builder.WriteString(fmt.Sprintf("  - %s\n", commentSafe(problem)))
+		}
+		builder.WriteString("-->\n\n")
+	}
+	builder.WriteString(editorHelp)
+	builder.WriteString("\n\n")
+	if parsed.Preamble != "" {
+		builder.WriteString(parsed.Preamble)
+		builder.WriteString("\n\n")
+	}
+
+	for _, field := range template.Body {
+		if field.Type == FieldTypeMarkdown {
+			if value := strings.TrimSpace(field.Attributes.Value); value != "" {
+				builder.WriteString(fmt.Sprintf("<!--\n%s\n-->\n\n", commentSafe(value)))
+			}
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("### %s\n\n", sectionLabel(field)))
+		if hint := fieldHint(field); hint != "" {
+			builder.WriteString(fmt.Sprintf("<!--\n%s\n-->\n\n", commentSafe(hint)))
+		}
+		if field.Type == FieldTypeCheckboxes {
+			// Every option is listed, to be checked in place
+			builder.WriteString(renderCheckboxes(field, parsed.Fields[field.ID]))
+			builder.WriteString("\n\n")
+		} else if value := parsed.Fields[field.ID]; value != "" {
+			builder.WriteString(value)
+			builder.WriteString("\n\n")
+		}
+	}
+
+	for _, section := range parsed.Unknown {
+		builder.WriteString(fmt.Sprintf("### %s\n\n", section.Label))
+		if section.Value != "" {
+			builder.WriteString(section.Value)
+			builder.WriteString("\n\n")
+		}
+	}
+
+	return builder.String()
+}
+
+// ParseEditorBuffer reads a buffer written by RenderEditorBuffer back into
+// field values, after dropping the HTML comments outside fenced code blocks.
+func ParseEditorBuffer(template *IssueTemplate, buffer string) *ParsedBody {
+	return ParseBody(template, stripComments(buffer))
+}
+
+// fieldHint describes a field for the comment under its heading
+func fieldHint(field BodyField) string {
+	var lines []string
+	if field.Validations.Required {
+		lines = append(lines, "Required.")
+	}
+	if field.Type == FieldTypeCheckboxes {
+		lines = append(lines, "Check the options that apply with [x].")
+		for _, option := range field.CheckboxOptions() {
+			if option.Required {
+				lines = append(lines, fmt.Sprintf("'%s' must be checked.", option.Label))
+			}
+		}
+	}
+	if description := strings.TrimSpace(field.Attributes.Description); description != "" {
+		lines = append(lines, description)
+	}
+	if field.Type == FieldTypeDropdown && len(field.Attributes.Options) > 0 {
+		if field.Attributes.Multiple {
+			lines = append(lines, "One or more of, comma-separated: "+strings.Join(field.Attributes.Options, ", "))
+		} else {
+			lines = append(lines, "One of: "+strings.Join(field.Attributes.Options, ", "))
+		}
+		if option := field.DefaultOption(); option != "" {
+			lines = append(lines, fmt.Sprintf("Defaults to '%s' if left empty.", option))
+		}
+	}
+	lines = append(lines, field.RuleDescriptions()...)
+	if placeholder := strings.TrimSpace(field.Attributes.Placeholder); placeholder != "" {
+		lines = append(lines, "Example:\n"+placeholder)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commentSafe keeps text from ending the HTML comment it is written in
+func commentSafe(text string) string {
+	return strings.ReplaceAll(text, "-->", "-- >")
+}
+
+// stripComments removes HTML comments outside fenced code blocks. Lines left
+// blank by removing a comment are dropped too.
+func stripComments(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var kept []string
+	inFence, inComment := false, false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !inComment && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			inFence = !inFence
+		}
+		if inFence {
+			kept = append(kept, line)
+			continue
+		}
+
+		var builder strings.Builder
+		rest := line
+		for rest != "" {
+			if inComment {
+				_, after, found := strings.Cut(rest, "-->")
+				if !found {
+					rest = ""
+					break
+				}
+				inComment = false
+				rest = after
+				continue
+			}
+			before, after, found := strings.Cut(rest, "<!--")
+			builder.WriteString(before)
+			if !found {
+				break
+			}
+			inComment = true
+			rest = after
+		}
+
+		stripped := builder.String()
+		if strings.TrimSpace(stripped) == "" && strings.TrimSpace(line) != "" {
+			continue
+		}
+		kept = append(kept, stripped)
+	}
+
+	return strings.Join(kept, "\n")
+}
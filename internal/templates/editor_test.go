@@ -0,0 +1,84 @@
+package templates
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestStripComments(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "no comments", text: "### Notes\n\nLater", want: "### Notes\n\nLater"},
+		{name: "comment line is dropped", text: "### Notes\n<!-- Optional -->\nLater", want: "### Notes\nLater"},
+		{name: "inline comment", text: "Later <!-- or never --> maybe", want: "Later  maybe"},
+		{name: "multi-line comment", text: "a\n<!--\nFill in\nthe notes\n-->\nb", want: "a\nb"},
+		{name: "comment ending mid-line", text: "a <!-- one\ntwo --> b", want: "a \n b"},
+		{name: "several comments on a line", text: "<!-- x -->a<!-- y -->b", want: "ab"},
+		{name: "blank lines are kept", text: "a\n\n<!-- x -->\n\nb", want: "a\n\n\nb"},
+		{name: "comments in fenced code are kept", text: "```html\n<!-- markup -->\n```\n<!-- hint -->", want: "```html\n<!-- markup -->\n```"},
+		{name: "fence inside a comment is ignored", text: "<!--\n```\n-->\nvalue", want: "value"},
+		{name: "CRLF", text: "a\r\n<!-- x -->\r\nb", want: "a\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripComments(tt.text); got != tt.want {
+				t.Errorf("stripComments(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEditorBuffer(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   map[string]string
+		problems []string
+		edit     func(buffer string) string // Simulates the user's edits
+		want     map[string]string
+	}{
+		{
+			name:   "unchanged buffer round-trips",
+			fields: map[string]string{"description": "Add it\n\n```html\n<!-- kept -->\n```", "platforms": "iOS, Web", "checks": "Tests added"},
+			want:   map[string]string{"description": "Add it\n\n```html\n<!-- kept -->\n```", "platforms": "iOS, Web", "checks": "Tests added"},
+		},
+		{
+			name:     "problems and hints are not values",
+			fields:   map[string]string{},
+			problems: []string{"required field 'description' is missing", "a --> in a problem"},
+			want:     map[string]string{},
+		},
+		{
+			name:   "filled in sections and checked boxes",
+			fields: map[string]string{},
+			edit: func(buffer string) string {
+				buffer = strings.Replace(buffer, "### 📝 Description\n", "### 📝 Description\n\nAdd it", 1)
+				buffer = strings.Replace(buffer, "- [ ] Tests added", "- [x] Tests added", 1)
+				return strings.Replace(buffer, "### Version\n", "### Version\n\nv1.0.0 <!-- semver -->", 1)
+			},
+			want: map[string]string{"description": "Add it", "checks": "Tests added", "version": "v1.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := testTemplate(t)
+			buffer := RenderEditorBuffer(template, &ParsedBody{Fields: tt.fields}, tt.problems)
+			if tt.edit != nil {
+				buffer = tt.edit(buffer)
+			}
+
+			parsed := ParseEditorBuffer(template, buffer)
+			if !maps.Equal(parsed.Fields, tt.want) {
+				t.Errorf("Fields = %q, want %q\nbuffer:\n%s", parsed.Fields, tt.want, buffer)
+			}
+			if len(parsed.Unknown) > 0 || parsed.Preamble != "" {
+				t.Errorf("Unknown = %q, Preamble = %q, want none", parsed.Unknown, parsed.Preamble)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// keyPattern matches the marker written by KeyMarker
+var keyPattern = regexp.MustCompile(`<!--\s*gh-project-management key:\s*(\S+)\s*-->`)
+
+// KeyMarker returns a hidden HTML comment that stores key in an issue body.
+// It is written before the first section, so ParseBody keeps it in the
+// preamble and editing the issue doesn't drop it.
+func KeyMarker(key string) string {
+	return fmt.Sprintf("<!-- gh-project-management key: %s -->", key)
+}
+
+// WithKey prepends the marker of key to body. An empty key leaves body unchanged.
+func WithKey(body, key string) string {
+	if key == "" {
+		return body
+	}
+	return KeyMarker(key) + "\n\n" + body
+}
+
+// FindKey returns the key stored in body by KeyMarker, or "" if there is none
+func FindKey(body string) string {
+	match := keyPattern.FindStringSubmatch(body)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(match[1])
+}
+
+// typePattern matches the marker written by TypeMarker and the blank lines after it
+var typePattern = regexp.MustCompile(`<!--\s*gh-project-management type:\s*(.+?)\s*-->\n*`)
+
+// TypeMarker returns a hidden HTML comment that stores the template type of
+// a draft issue, which has no issue type until it is promoted
+func TypeMarker(issueType string) string {
+	return fmt.Sprintf("<!-- gh-project-management type: %s -->", issueType)
+}
+
+// WithType prepends the marker of issueType to body
+func WithType(body, issueType string) string {
+	return TypeMarker(issueType) + "\n\n" + body
+}
+
+// FindType returns the type stored in body by TypeMarker, or "" if there is
+// none, and body without the marker
+func FindType(body string) (string, string) {
+	match := typePattern.FindStringSubmatchIndex(body)
+	if match == nil {
+		return "", body
+	}
+	return body[match[2]:match[3]], body[:match[0]] + body[match[1]:]
+}
@@ -12,6 +12,9 @@ func ParseTemplate(content []byte) (*IssueTemplate, error) {
 	if err := yaml.Unmarshal(content, &template); err != nil {
 		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
+	if err := template.checkPatterns(); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
 	return &template, nil
 }
 
@@ -23,7 +26,7 @@ func (t *IssueTemplate) GetRequiredFields() []BodyField {
 		if field.Type == FieldTypeMarkdown {
 			continue
 		}
-		if field.Validations.Required {
+		if field.IsRequired() {
 			required = append(required, field)
 		}
 	}
@@ -41,30 +44,27 @@ func (t *IssueTemplate) GetAllInputFields() []BodyField {
 	return inputs
 }
 
-// ValidateFieldValue validates a field value based on field type and validations
+// ValidateFieldValue validates a field value based on field type and
+// validations, including the extended ones. Every violation is reported in
+// a ValidationError.
 func (f *BodyField) ValidateFieldValue(value string) error {
 	// Check required
 	if f.Validations.Required && value == "" {
-		return fmt.Errorf("field %s is required", f.ID)
+		return validationError([]string{fmt.Sprintf("field %s is required", f.ID)})
 	}
 
 	// Type-specific validation
+	var violations []string
 	switch f.Type {
 	case FieldTypeDropdown:
-		if value != "" && len(f.Attributes.Options) > 0 {
-			// Validate value is in options
-			found := false
-			for _, option := range f.Attributes.Options {
-				if option == value {
-					found = true
-					break
-				}
-			}
-			if !found {
-				return fmt.Errorf("invalid value for field %s: must be one of %v", f.ID, f.Attributes.Options)
-			}
-		}
+		violations = f.dropdownViolations(value)
+	case FieldTypeCheckboxes:
+		violations = f.checkboxesViolations(value)
+	}
+
+	if value != "" {
+		violations = append(violations, f.extendedViolations(value)...)
 	}
 
-	return nil
+	return validationError(violations)
 }
@@ -61,7 +61,6 @@ func GetTemplateFromLocalRepo(ctx context.Context, owner, repo, issueType string
 	}
 
 	if repoName == repo {
-		fmt.Println("Using templates from current repository: " + repoName)
 		return getTemplateFromCurrentDirectory(ctx, owner, repo, issueType)
 	}
 	return nil, "", fmt.Errorf("failed to get template from local repository")
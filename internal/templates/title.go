@@ -0,0 +1,39 @@
+package templates
+
+import (
+	"regexp"
+	"strings"
+)
+
+// titlePlaceholder matches a placeholder of a title pattern, like {{summary}}
+var titlePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// Title placeholders that are not template fields
+const (
+	TitleKeyType = "type" // GitHub issue type name, e.g. "User Story"
+	TitleKeyTeam = "team" // Team project field
+)
+
+// HasTitlePattern reports whether the template's title has placeholders.
+// A title without them is only the initial value of the title prompt, like
+// GitHub issue forms use it.
+func (t *IssueTemplate) HasTitlePattern() bool {
+	return titlePlaceholder.MatchString(t.Title)
+}
+
+// RenderTitle replaces the placeholders of the template's title, e.g.
+// "[{{team}}] {{summary}}", with values keyed by field ID, TitleKeyType or
+// TitleKeyTeam. Only the first line of a value is used. Placeholders without
+// a value are left as they are and returned in missing.
+func (t *IssueTemplate) RenderTitle(values map[string]string) (title string, missing []string) {
+	title = titlePlaceholder.ReplaceAllStringFunc(t.Title, func(placeholder string) string {
+		name := titlePlaceholder.FindStringSubmatch(placeholder)[1]
+		value, _, _ := strings.Cut(strings.TrimSpace(values[name]), "\n")
+		if value = strings.TrimSpace(value); value == "" {
+			missing = append(missing, name)
+			return placeholder
+		}
+		return value
+	})
+	return title, missing
+}
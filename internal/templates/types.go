@@ -17,16 +17,27 @@ type BodyField struct {
 	ID          string          `yaml:"id"`
 	Attributes  FieldAttributes `yaml:"attributes"`
 	Validations Validations     `yaml:"validations"`
+	Extension   FieldExtension  `yaml:"x-gh-project-management"`
 }
 
-// FieldAttributes contains the configuration for a field
+// FieldAttributes contains the configuration for a field. Options are read
+// by UnmarshalYAML, as checkboxes options are objects instead of strings.
 type FieldAttributes struct {
-	Label       string   `yaml:"label"`
-	Description string   `yaml:"description"`
-	Placeholder string   `yaml:"placeholder"`
-	Value       string   `yaml:"value"`    // For markdown type
-	Options     []string `yaml:"options"`  // For dropdown type
-	Multiple    bool     `yaml:"multiple"` // For checkboxes/dropdown
+	Label       string           `yaml:"label"`
+	Description string           `yaml:"description"`
+	Placeholder string           `yaml:"placeholder"`
+	Value       string           `yaml:"value"`    // For markdown type
+	Options     []string         `yaml:"-"`        // For dropdown and checkboxes types: option labels
+	Checkboxes  []CheckboxOption `yaml:"-"`        // For checkboxes type
+	Multiple    bool             `yaml:"multiple"` // For dropdown type: several options can be selected
+	Default     *int             `yaml:"default"`  // For dropdown type: index of the preselected option
+}
+
+// CheckboxOption is an option of a checkboxes field. A required option must
+// be checked.
+type CheckboxOption struct {
+	Label    string `yaml:"label"`
+	Required bool   `yaml:"required"`
 }
 
 // Validations contains validation rules for a field
@@ -34,6 +45,11 @@ type Validations struct {
 	Required bool `yaml:"required"`
 }
 
+// FieldExtension holds the settings of a field that only this tool reads
+type FieldExtension struct {
+	Validations ExtendedValidations `yaml:"validations"`
+}
+
 // FieldType constants
 const (
 	FieldTypeMarkdown   = "markdown"
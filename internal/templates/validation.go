@@ -0,0 +1,172 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// issueReference matches a reference to an issue: #123, owner/repo#123 or an
+// issue URL on any host, such as github.com or a GitHub Enterprise Server
+var issueReference = regexp.MustCompile(`(^|[^\w/])([\w.-]+/[\w.-]+)?#\d+\b|https://[\w.-]+(:\d+)?/[\w.-]+/[\w.-]+/issues/\d+`)
+
+// ExtendedValidations are the rules a field can declare besides required, in
+// the x-gh-project-management block of the template, which GitHub ignores:
+//
+//	x-gh-project-management:
+//	  validations:
+//	    pattern: '^v\d+\.\d+\.\d+$'
+//	    min_length: 6
+//
+// Rules only apply to fields with a value.
+type ExtendedValidations struct {
+	Pattern        string   `yaml:"pattern"`         // Regular expression the value must match
+	MinLength      *int     `yaml:"min_length"`      // In characters, ignoring surrounding whitespace
+	MaxLength      *int     `yaml:"max_length"`      // In characters, ignoring surrounding whitespace
+	Checklist      bool     `yaml:"checklist"`       // The value must contain a task list item
+	IssueReference bool     `yaml:"issue_reference"` // The value must reference an issue
+	Min            *float64 `yaml:"min"`             // The value must be a number of at least Min
+	Max            *float64 `yaml:"max"`             // The value must be a number of at most Max
+}
+
+// ValidationError lists every rule the field values break
+type ValidationError struct {
+	Violations []string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Violations) == 1 {
+		return e.Violations[0]
+	}
+	return fmt.Sprintf("%d problems: %s", len(e.Violations), strings.Join(e.Violations, "; "))
+}
+
+// Violations returns the violations listed by a ValidationError in err, or
+// the message of err if it is another error
+func Violations(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Violations
+	}
+	return []string{err.Error()}
+}
+
+// validationError returns a ValidationError with violations, or nil if there are none
+func validationError(violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
+
+// RuleDescriptions describes the extended validations of the field, one per
+// rule, for help texts
+func (f *BodyField) RuleDescriptions() []string {
+	rules := f.Extension.Validations
+	var descriptions []string
+	if rules.Pattern != "" {
+		descriptions = append(descriptions, fmt.Sprintf("Must match the pattern %s.", rules.Pattern))
+	}
+	switch {
+	case rules.MinLength != nil && rules.MaxLength != nil:
+		descriptions = append(descriptions, fmt.Sprintf("Between %d and %d characters.", *rules.MinLength, *rules.MaxLength))
+	case rules.MinLength != nil:
+		descriptions = append(descriptions, fmt.Sprintf("At least %d characters.", *rules.MinLength))
+	case rules.MaxLength != nil:
+		descriptions = append(descriptions, fmt.Sprintf("At most %d characters.", *rules.MaxLength))
+	}
+	if rules.Checklist {
+		descriptions = append(descriptions, "Must contain a checklist, like \"- [ ] item\".")
+	}
+	if rules.IssueReference {
+		descriptions = append(descriptions, "Must reference an issue, like #123 or owner/repo#123.")
+	}
+	switch {
+	case rules.Min != nil && rules.Max != nil:
+		descriptions = append(descriptions, fmt.Sprintf("A number from %s to %s.", formatNumber(*rules.Min), formatNumber(*rules.Max)))
+	case rules.Min != nil:
+		descriptions = append(descriptions, fmt.Sprintf("A number of at least %s.", formatNumber(*rules.Min)))
+	case rules.Max != nil:
+		descriptions = append(descriptions, fmt.Sprintf("A number of at most %s.", formatNumber(*rules.Max)))
+	}
+	return descriptions
+}
+
+// checkPatterns compiles the patterns of the template's fields, so a broken
+// pattern is reported when the template is loaded
+func (t *IssueTemplate) checkPatterns() error {
+	for _, field := range t.Body {
+		if pattern := field.Extension.Validations.Pattern; pattern != "" {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("field %s: invalid pattern: %w", field.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// extendedViolations checks a non-empty value against the extended
+// validations of the field
+func (f *BodyField) extendedViolations(value string) []string {
+	rules := f.Extension.Validations
+	var violations []string
+
+	if rules.Pattern != "" {
+		re, err := regexp.Compile(rules.Pattern)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("field %s: invalid pattern: %v", f.ID, err))
+		} else if !re.MatchString(value) {
+			violations = append(violations, fmt.Sprintf("field %s must match the pattern %s", f.ID, rules.Pattern))
+		}
+	}
+
+	length := utf8.RuneCountInString(strings.TrimSpace(value))
+	if rules.MinLength != nil && length < *rules.MinLength {
+		violations = append(violations, fmt.Sprintf("field %s must be at least %d characters long (it has %d)", f.ID, *rules.MinLength, length))
+	}
+	if rules.MaxLength != nil && length > *rules.MaxLength {
+		violations = append(violations, fmt.Sprintf("field %s must be at most %d characters long (it has %d)", f.ID, *rules.MaxLength, length))
+	}
+
+	if rules.Checklist && !hasTaskListItem(value) {
+		violations = append(violations, fmt.Sprintf("field %s must contain a checklist, like \"- [ ] item\"", f.ID))
+	}
+	if rules.IssueReference && !issueReference.MatchString(value) {
+		violations = append(violations, fmt.Sprintf("field %s must reference an issue, like #123 or owner/repo#123", f.ID))
+	}
+
+	if rules.Min != nil || rules.Max != nil {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		switch {
+		case err != nil:
+			violations = append(violations, fmt.Sprintf("field %s must be a number", f.ID))
+		case rules.Min != nil && number < *rules.Min:
+			violations = append(violations, fmt.Sprintf("field %s must be at least %s", f.ID, formatNumber(*rules.Min)))
+		case rules.Max != nil && number > *rules.Max:
+			violations = append(violations, fmt.Sprintf("field %s must be at most %s", f.ID, formatNumber(*rules.Max)))
+		}
+	}
+
+	return violations
+}
+
+// hasTaskListItem reports whether text has a line of a markdown task list
+func hasTaskListItem(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if match := taskListItem.FindStringSubmatch(line); match != nil && match[2] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// formatNumber writes a number without a trailing ".0"
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
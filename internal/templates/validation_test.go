@@ -0,0 +1,134 @@
+package templates
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestValidateFieldValue(t *testing.T) {
+	intp := func(i int) *int { return &i }
+	floatp := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name  string
+		rules ExtendedValidations
+		value string
+		want  []string
+	}{
+		{
+			name:  "valid value",
+			rules: ExtendedValidations{Pattern: `^v\d+\.\d+\.\d+$`, MinLength: intp(6)},
+			value: "v1.2.0",
+		},
+		{
+			name:  "pattern and length together",
+			rules: ExtendedValidations{Pattern: `^v\d+\.\d+\.\d+$`, MinLength: intp(6)},
+			value: "1.0",
+			want: []string{
+				`field version must match the pattern ^v\d+\.\d+\.\d+$`,
+				"field version must be at least 6 characters long (it has 3)",
+			},
+		},
+		{
+			name:  "every text rule",
+			rules: ExtendedValidations{MaxLength: intp(10), Checklist: true, IssueReference: true},
+			value: "Depends on nothing yet",
+			want: []string{
+				"field version must be at most 10 characters long (it has 22)",
+				`field version must contain a checklist, like "- [ ] item"`,
+				"field version must reference an issue, like #123 or owner/repo#123",
+			},
+		},
+		{
+			name:  "checklist and issue reference",
+			rules: ExtendedValidations{Checklist: true, IssueReference: true},
+			value: "- [ ] After acme/pm#44",
+		},
+		{
+			name:  "issue URL on an enterprise host",
+			rules: ExtendedValidations{IssueReference: true},
+			value: "After https://github.example.com/acme/pm/issues/44",
+		},
+		{
+			name:  "pull request URL",
+			rules: ExtendedValidations{IssueReference: true},
+			value: "After https://github.com/acme/pm/pull/44",
+			want:  []string{"field version must reference an issue, like #123 or owner/repo#123"},
+		},
+		{
+			name:  "not a number",
+			rules: ExtendedValidations{Min: floatp(1), Max: floatp(5)},
+			value: "three",
+			want:  []string{"field version must be a number"},
+		},
+		{
+			name:  "number out of range",
+			rules: ExtendedValidations{Min: floatp(1), Max: floatp(5)},
+			value: "5.5",
+			want:  []string{"field version must be at most 5"},
+		},
+		{
+			name:  "empty value skips the rules",
+			rules: ExtendedValidations{Pattern: `^v`, MinLength: intp(6), Checklist: true},
+			value: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := BodyField{Type: FieldTypeInput, ID: "version", Extension: FieldExtension{Validations: tt.rules}}
+			err := field.ValidateFieldValue(tt.value)
+			if got := Violations(err); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFieldsReportsEveryViolation(t *testing.T) {
+	template := testTemplate(t)
+
+	err := ValidateFields(template, map[string]string{
+		"version":   "1.0",
+		"platforms": "iOS, Windows",
+		"checks":    "Docs updated",
+	})
+
+	want := []string{
+		"required field 'description' (📝 Description) is missing",
+		`field version must match the pattern ^v\d+\.\d+\.\d+$`,
+		"field version must be at least 6 characters long (it has 3)",
+		"invalid value for field platforms: 'Windows' is not an option (options: iOS, Android, Web)",
+		"field checks: option 'Tests added' must be checked",
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateFields returned %v, want a ValidationError", err)
+	}
+	if !slices.Equal(validationErr.Violations, want) {
+		t.Errorf("violations = %q, want %q", validationErr.Violations, want)
+	}
+	if got, want := err.Error(), "5 problems: "+want[0]; len(got) < len(want) || got[:len(want)] != want {
+		t.Errorf("Error() = %q, want it to start with %q", got, want)
+	}
+
+	if err := ValidateFields(template, map[string]string{"description": "Add it", "checks": "Tests added"}); err != nil {
+		t.Errorf("ValidateFields with valid fields: %v", err)
+	}
+}
+
+func TestParseTemplateRejectsInvalidPattern(t *testing.T) {
+	_, err := ParseTemplate([]byte(`
+name: Task
+body:
+  - type: input
+    id: version
+    x-gh-project-management:
+      validations:
+        pattern: '^v(\d+'
+`))
+	if err == nil {
+		t.Fatal("ParseTemplate accepted an invalid pattern")
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // CollectContextConfiguration runs an interactive form to collect all context configuration
-func CollectContextConfiguration() (*config.Context, error) {
+func CollectContextConfiguration(client gh.Client) (*config.Context, error) {
 	var (
 		ownerType   config.OwnerType
 		owner       string
@@ -54,7 +54,7 @@ func CollectContextConfiguration() (*config.Context, error) {
 
 		fmt.Println()
 		fmt.Println("🔍 Getting your username...")
-		username, err := gh.GetCurrentUser()
+		username, err := client.GetCurrentUser()
 		if err != nil {
 			return nil, fmt.Errorf("error getting current user: %w", err)
 		}
@@ -63,7 +63,7 @@ func CollectContextConfiguration() (*config.Context, error) {
 		// Fetch user repositories
 		fmt.Println()
 		fmt.Printf("🔍 Fetching your repositories...\n")
-		repos, err = gh.ListUserRepositories()
+		repos, err = client.ListUserRepositories()
 		if err != nil {
 			return nil, fmt.Errorf("error fetching repositories: %w", err)
 		}
@@ -72,7 +72,7 @@ func CollectContextConfiguration() (*config.Context, error) {
 		// Fetch user projects
 		fmt.Println()
 		fmt.Printf("🔍 Fetching your projects...\n")
-		projects, err = gh.ListUserProjects()
+		projects, err = client.ListUserProjects()
 		if err != nil {
 			return nil, fmt.Errorf("error fetching projects: %w", err)
 		}
@@ -87,7 +87,7 @@ func CollectContextConfiguration() (*config.Context, error) {
 		fmt.Println()
 		fmt.Println("🔍 Fetching your organizations...")
 
-		orgs, err := gh.ListOrganizations()
+		orgs, err := client.ListOrganizations()
 		if err != nil {
 			return nil, fmt.Errorf("error fetching organizations: %w", err)
 		}
@@ -125,7 +125,7 @@ func CollectContextConfiguration() (*config.Context, error) {
 		// Fetch organization repositories
 		fmt.Println()
 		fmt.Printf("🔍 Fetching repositories for %s...\n", owner)
-		repos, err = gh.ListOrgRepositories(owner)
+		repos, err = client.ListOrgRepositories(owner)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching repositories: %w", err)
 		}
@@ -134,7 +134,7 @@ func CollectContextConfiguration() (*config.Context, error) {
 		// Fetch organization projects
 		fmt.Println()
 		fmt.Printf("🔍 Fetching projects for %s...\n", owner)
-		projects, err = gh.ListOrgProjects(owner)
+		projects, err = client.ListOrgProjects(owner)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching projects: %w", err)
 		}
@@ -260,7 +260,7 @@ func CollectContextConfiguration() (*config.Context, error) {
 	fmt.Println("🔧 Checking Team custom field in project...")
 
 	bgCtx := context.Background()
-	teamField, err := gh.EnsureTeamField(bgCtx, projectNodeID, teamRepos)
+	teamField, err := gh.EnsureTeamField(bgCtx, client, projectNodeID, teamRepos)
 	if err != nil {
 		fmt.Printf("⚠️  Warning: Failed to ensure Team custom field: %v\n", err)
 		fmt.Println("You may need to create it manually in the project settings.")
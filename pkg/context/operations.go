@@ -6,11 +6,13 @@ import (
 
 	"github.com/Zytera/gh-project-management/internal/config"
 	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/gh/cache"
 )
 
 // AddContextParams contains parameters for adding a new context
 type AddContextParams struct {
 	Name        string
+	Host        string
 	OwnerType   config.OwnerType
 	Owner       string
 	ProjectID   string
@@ -20,7 +22,7 @@ type AddContextParams struct {
 }
 
 // AddContext adds a new context to the configuration
-func AddContext(params AddContextParams) error {
+func AddContext(client gh.Client, params AddContextParams) error {
 	globalConfig, err := config.LoadGlobal()
 	if err != nil {
 		return err
@@ -32,6 +34,7 @@ func AddContext(params AddContextParams) error {
 
 	// Create context
 	ctx := &config.Context{
+		Host:        params.Host,
 		OwnerType:   params.OwnerType,
 		Owner:       params.Owner,
 		ProjectID:   params.ProjectID,
@@ -49,17 +52,17 @@ func AddContext(params AddContextParams) error {
 		// Get project node ID
 		var projects []gh.Project
 		if params.OwnerType == config.OwnerTypeOrg {
-			projects, err = gh.ListOrgProjects(params.Owner)
+			projects, err = client.ListOrgProjects(params.Owner)
 		} else {
-			projects, err = gh.ListUserProjects()
+			projects, err = client.ListUserProjects()
 		}
 
 		if err == nil {
 			for _, p := range projects {
 				if fmt.Sprintf("%d", p.Number) == params.ProjectID {
 					bgCtx := context.Background()
-					_, _ = gh.EnsureTeamField(bgCtx, p.ID, params.TeamRepos)
-					_, _ = gh.EnsurePriorityField(bgCtx, p.ID)
+					_, _ = gh.EnsureTeamField(bgCtx, client, p.ID, params.TeamRepos)
+					_, _ = gh.EnsurePriorityField(bgCtx, client, p.ID)
 					break
 				}
 			}
@@ -94,7 +97,16 @@ func DeleteContext(name string) error {
 		globalConfig.CurrentContext = ""
 	}
 
-	return config.Save(globalConfig)
+	if err := config.Save(globalConfig); err != nil {
+		return err
+	}
+
+	// Drop the context's cached metadata
+	cachePath, err := config.GetCachePath(name)
+	if err != nil {
+		return err
+	}
+	return cache.Clear(cachePath)
 }
 
 // SwitchContext switches to a different context
@@ -139,6 +151,7 @@ func GetCurrentContext() (*config.Context, string, error) {
 // UpdateContextParams contains parameters for updating a context
 type UpdateContextParams struct {
 	ContextName  string
+	Host         *string           // Optional: new GitHub host
 	ProjectID    *string           // Optional: new project ID
 	ProjectName  *string           // Optional: new project name
 	DefaultRepo  *string           // Optional: new default repository
@@ -148,7 +161,7 @@ type UpdateContextParams struct {
 
 // UpdateContext updates an existing context configuration
 // and verifies/creates custom fields if teams are modified
-func UpdateContext(params UpdateContextParams) error {
+func UpdateContext(client gh.Client, params UpdateContextParams) error {
 	globalConfig, err := config.LoadGlobal()
 	if err != nil {
 		return err
@@ -161,6 +174,12 @@ func UpdateContext(params UpdateContextParams) error {
 
 	teamsModified := false
 
+	// Update host if provided
+	hostChanged := params.Host != nil && *params.Host != ctx.Host
+	if params.Host != nil {
+		ctx.Host = *params.Host
+	}
+
 	// Update project ID if provided
 	if params.ProjectID != nil {
 		ctx.ProjectID = *params.ProjectID
@@ -203,9 +222,9 @@ func UpdateContext(params UpdateContextParams) error {
 		// Get project node ID
 		var projects []gh.Project
 		if ctx.OwnerType == config.OwnerTypeOrg {
-			projects, err = gh.ListOrgProjects(ctx.Owner)
+			projects, err = client.ListOrgProjects(ctx.Owner)
 		} else {
-			projects, err = gh.ListUserProjects()
+			projects, err = client.ListUserProjects()
 		}
 
 		if err == nil {
@@ -213,7 +232,7 @@ func UpdateContext(params UpdateContextParams) error {
 				if fmt.Sprintf("%d", p.Number) == ctx.ProjectID {
 					bgCtx := context.Background()
 					// Ensure Team field has all teams (existing + new)
-					if _, err := gh.EnsureTeamField(bgCtx, p.ID, ctx.TeamRepos); err != nil {
+					if _, err := gh.EnsureTeamField(bgCtx, client, p.ID, ctx.TeamRepos); err != nil {
 						return fmt.Errorf("failed to update Team field: %w", err)
 					}
 					break
@@ -226,5 +245,17 @@ func UpdateContext(params UpdateContextParams) error {
 
 	// Save updated context
 	globalConfig.Contexts[params.ContextName] = ctx
-	return config.Save(globalConfig)
+	if err := config.Save(globalConfig); err != nil {
+		return err
+	}
+
+	// Cached node IDs belong to the old host
+	if hostChanged {
+		cachePath, err := config.GetCachePath(params.ContextName)
+		if err != nil {
+			return err
+		}
+		return cache.Clear(cachePath)
+	}
+	return nil
 }
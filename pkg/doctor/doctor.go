@@ -0,0 +1,312 @@
+// Package doctor runs preflight checks on the GitHub token and the active
+// context, so configuration problems show up before an issue is half-created.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+)
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Check is the result of a single check
+type Check struct {
+	Name   string
+	Status Status
+	Detail string
+	Hint   string
+}
+
+// Report is the result of all checks
+type Report struct {
+	Checks []Check
+}
+
+// Failed returns the number of failed checks
+func (r *Report) Failed() int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == StatusFail {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *Report) add(name string, status Status, detail, hint string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Detail: detail, Hint: hint})
+}
+
+func (r *Report) addError(name string, err error, fallbackHint string) {
+	hint := gh.Hint(err)
+	if hint == "" {
+		hint = fallbackHint
+	}
+	r.add(name, StatusFail, err.Error(), hint)
+}
+
+// RequiredScopes are the OAuth scopes the extension needs
+var RequiredScopes = []string{"project", "read:org", "repo"}
+
+// impliedScopes lists scopes that also grant a required scope
+var impliedScopes = map[string][]string{
+	"read:org": {"write:org", "admin:org"},
+}
+
+// writePermissions are the repository permissions that allow creating and transferring issues
+var writePermissions = map[string]bool{"ADMIN": true, "MAINTAIN": true, "WRITE": true}
+
+// Run checks the token scopes, the project, the repositories, the Team and
+// Priority fields and (for organizations) issue types of a context
+func Run(ctx context.Context, client gh.Client, cfg *config.Config) *Report {
+	report := &Report{}
+
+	checkScopes(ctx, client, report)
+	projectID := checkProject(ctx, client, cfg, report)
+	checkRepositories(ctx, client, cfg, report)
+	if projectID != "" {
+		checkFields(ctx, client, cfg, projectID, report)
+	}
+	checkIssueTypes(ctx, client, cfg, report)
+
+	return report
+}
+
+func checkScopes(ctx context.Context, client gh.Client, report *Report) {
+	const name = "Token scopes"
+
+	scopes, err := client.GetTokenScopes(ctx)
+	if err != nil {
+		report.addError(name, err, "Run: gh auth login")
+		return
+	}
+	if scopes == nil {
+		report.add(name, StatusWarn, "token does not report scopes (fine-grained or app token)",
+			"Make sure the token can read and write projects, issues and organization members")
+		return
+	}
+
+	granted := make(map[string]bool)
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	var missing []string
+	for _, required := range RequiredScopes {
+		if granted[required] {
+			continue
+		}
+		implied := false
+		for _, scope := range impliedScopes[required] {
+			implied = implied || granted[scope]
+		}
+		if !implied {
+			missing = append(missing, required)
+		}
+	}
+
+	if len(missing) > 0 {
+		report.add(name, StatusFail, fmt.Sprintf("missing %s", strings.Join(missing, ", ")),
+			fmt.Sprintf("Run: gh auth refresh -s %s", strings.Join(missing, ",")))
+		return
+	}
+	report.add(name, StatusOK, strings.Join(scopes, ", "), "")
+}
+
+// checkProject resolves the context's project and returns its node ID, or "" on failure
+func checkProject(ctx context.Context, client gh.Client, cfg *config.Config, report *Report) string {
+	name := fmt.Sprintf("Project #%s", cfg.ProjectID)
+
+	projectNumber, err := strconv.Atoi(cfg.ProjectID)
+	if err != nil {
+		report.add(name, StatusFail, fmt.Sprintf("invalid project ID '%s'", cfg.ProjectID),
+			"Set a numeric project ID with 'gh project-management context update <name> --project-id <number>'")
+		return ""
+	}
+
+	var projectID string
+	if cfg.OwnerType == config.OwnerTypeOrg {
+		projectID, err = client.GetProjectNodeID(ctx, cfg.Owner, projectNumber)
+	} else {
+		projectID, err = client.GetUserProjectNodeID(ctx, projectNumber)
+	}
+	if err != nil {
+		report.addError(name, err, "")
+		return ""
+	}
+
+	report.add(name, StatusOK, fmt.Sprintf("%s (%s)", cfg.ProjectName, projectID), "")
+	return projectID
+}
+
+func checkRepositories(ctx context.Context, client gh.Client, cfg *config.Config, report *Report) {
+	checkRepository(ctx, client, cfg.Owner, cfg.DefaultRepo, "Default repository", report)
+
+	teams := make([]string, 0, len(cfg.TeamRepos))
+	for team := range cfg.TeamRepos {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	for _, team := range teams {
+		checkRepository(ctx, client, cfg.Owner, cfg.TeamRepos[team], fmt.Sprintf("%s repository", team), report)
+	}
+}
+
+func checkRepository(ctx context.Context, client gh.Client, owner, repo, label string, report *Report) {
+	name := fmt.Sprintf("%s %s/%s", label, owner, repo)
+
+	permission, err := client.GetRepositoryPermission(ctx, owner, repo)
+	if err != nil {
+		report.addError(name, err, "")
+		return
+	}
+	if !writePermissions[permission] {
+		report.add(name, StatusFail, fmt.Sprintf("%s access, write access is required", strings.ToLower(permission)),
+			"Ask a repository admin for write access")
+		return
+	}
+	report.add(name, StatusOK, fmt.Sprintf("%s access", strings.ToLower(permission)), "")
+}
+
+func checkFields(ctx context.Context, client gh.Client, cfg *config.Config, projectID string, report *Report) {
+	fields, err := client.GetProjectFields(ctx, projectID)
+	if err != nil {
+		report.addError("Project fields", err, "")
+		return
+	}
+
+	var teams []string
+	for team := range cfg.TeamRepos {
+		teams = append(teams, team)
+	}
+	checkFieldOptions(gh.FindFieldByName(fields, "Team"), "Team", teams,
+		fmt.Sprintf("Run 'gh project-management context update %s --team-repos <Team>=<repo>' to add missing teams", cfg.Name),
+		report)
+
+	var priorities []string
+	for priority := range gh.PriorityLevels {
+		priorities = append(priorities, priority)
+	}
+	checkFieldOptions(gh.FindFieldByName(fields, "Priority"), "Priority", priorities,
+		"Create the Priority field or its missing options in the project settings",
+		report)
+}
+
+func checkFieldOptions(field *gh.Field, fieldName string, expected []string, hint string, report *Report) {
+	name := fmt.Sprintf("%s field", fieldName)
+
+	if field == nil {
+		report.add(name, StatusFail, "field not found in project", hint)
+		return
+	}
+
+	existing := make(map[string]bool)
+	for _, opt := range field.Options {
+		existing[opt.Name] = true
+	}
+	expectedSet := make(map[string]bool)
+	var missing []string
+	for _, value := range expected {
+		expectedSet[value] = true
+		if !existing[value] {
+			missing = append(missing, value)
+		}
+	}
+	sort.Strings(missing)
+
+	if len(missing) > 0 {
+		report.add(name, StatusFail, fmt.Sprintf("missing options: %s", strings.Join(missing, ", ")), hint)
+		return
+	}
+
+	var extra []string
+	for _, opt := range field.Options {
+		if !expectedSet[opt.Name] {
+			extra = append(extra, opt.Name)
+		}
+	}
+	if len(extra) > 0 && fieldName == "Team" {
+		report.add(name, StatusWarn, fmt.Sprintf("options without a team repository: %s", strings.Join(extra, ", ")),
+			"Issues assigned to these teams are not transferred automatically")
+		return
+	}
+	report.add(name, StatusOK, fmt.Sprintf("%d options", len(field.Options)), "")
+}
+
+// templateIssueTypes are the issue types the built-in templates are created with
+var templateIssueTypes = []string{"Epic", "User Story", "Task", "Bug", "Feature"}
+
+func checkIssueTypes(ctx context.Context, client gh.Client, cfg *config.Config, report *Report) {
+	const name = "Issue types"
+
+	if cfg.OwnerType != config.OwnerTypeOrg {
+		report.add(name, StatusOK, "skipped, issue types are an organization feature", "")
+		return
+	}
+
+	issueTypes, err := client.ListOrgIssueTypes(ctx, cfg.Owner)
+	if errors.Is(err, gh.ErrIssueTypesUnavailable) {
+		report.add(name, StatusWarn, "not enabled for "+cfg.Owner, gh.Hint(err))
+		return
+	}
+	if err != nil {
+		report.addError(name, err, "")
+		return
+	}
+
+	enabled := make(map[string]bool)
+	count := 0
+	for _, issueType := range issueTypes {
+		enabled[issueType.Name] = issueType.IsEnabled
+		if issueType.IsEnabled {
+			count++
+		}
+	}
+	if len(issueTypes) > 0 && count == 0 {
+		report.add(name, StatusWarn, fmt.Sprintf("none of the %d issue types of %s are enabled", len(issueTypes), cfg.Owner),
+			"Enable issue types in the organization settings; issues are created without a type until then")
+		return
+	}
+
+	var missing, disabled []string
+	for _, typeName := range templateIssueTypes {
+		isEnabled, ok := enabled[typeName]
+		switch {
+		case !ok:
+			missing = append(missing, typeName)
+		case !isEnabled:
+			disabled = append(disabled, typeName)
+		}
+	}
+
+	var problems, hints []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+		hints = append(hints, "Missing types are created when first used, which needs an organization admin")
+	}
+	if len(disabled) > 0 {
+		problems = append(problems, "disabled "+strings.Join(disabled, ", "))
+		hints = append(hints, "Enable the disabled types in the organization settings")
+	}
+	if len(problems) > 0 {
+		report.add(name, StatusWarn, strings.Join(problems, "; "), strings.Join(hints, ". "))
+		return
+	}
+
+	report.add(name, StatusOK, fmt.Sprintf("%d enabled, including %s", count, strings.Join(templateIssueTypes, ", ")), "")
+}
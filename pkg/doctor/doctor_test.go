@@ -0,0 +1,207 @@
+package doctor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/gh/fake"
+)
+
+// setupDoctorTest registers a healthy acme context: a project with the Team
+// and Priority fields, the pm and backend repositories and every issue type
+// of the templates
+func setupDoctorTest(t *testing.T) (*fake.Client, *config.Config) {
+	t.Helper()
+	client := fake.New("me")
+	client.AddOrganization("acme", "Acme")
+	client.AddRepo("acme", "pm")
+	client.AddRepo("acme", "backend")
+	for _, name := range templateIssueTypes {
+		client.AddIssueType("acme", name, true)
+	}
+
+	p := client.AddProject("acme", 1, "Roadmap")
+	ctx := context.Background()
+	if _, err := client.CreateSingleSelectField(ctx, p.ID, "Team", map[string]gh.FieldColor{"Backend": gh.ColorBlue}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateSingleSelectField(ctx, p.ID, "Priority", gh.PriorityLevels); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		Name:        "acme",
+		OwnerType:   config.OwnerTypeOrg,
+		Owner:       "acme",
+		ProjectID:   "1",
+		ProjectName: "Roadmap",
+		DefaultRepo: "pm",
+		TeamRepos:   map[string]string{"Backend": "backend"},
+	}
+	return client, cfg
+}
+
+// findCheck returns the check called name
+func findCheck(t *testing.T, report *Report, name string) Check {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("no check called %q in %+v", name, report.Checks)
+	return Check{}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(client *fake.Client, cfg *config.Config)
+		check      string
+		wantStatus Status
+		wantDetail string
+		wantFailed int
+	}{
+		{
+			name:       "healthy context",
+			check:      "Issue types",
+			wantStatus: StatusOK,
+			wantDetail: "5 enabled, including Epic, User Story, Task, Bug, Feature",
+		},
+		{
+			name:       "missing scope",
+			setup:      func(client *fake.Client, cfg *config.Config) { client.Scopes = []string{"repo", "admin:org"} },
+			check:      "Token scopes",
+			wantStatus: StatusFail,
+			wantDetail: "missing project",
+			wantFailed: 1,
+		},
+		{
+			name: "read-only repository",
+			// Registering the repository again replaces it
+			setup:      func(client *fake.Client, cfg *config.Config) { client.AddRepo("acme", "backend").Permission = "READ" },
+			check:      "Backend repository acme/backend",
+			wantStatus: StatusFail,
+			wantDetail: "read access, write access is required",
+			wantFailed: 1,
+		},
+		{
+			name:       "team without an option",
+			setup:      func(client *fake.Client, cfg *config.Config) { cfg.TeamRepos["Frontend"] = "pm" },
+			check:      "Team field",
+			wantStatus: StatusFail,
+			wantDetail: "missing options: Frontend",
+			wantFailed: 1,
+		},
+		{
+			name:       "unknown project",
+			setup:      func(client *fake.Client, cfg *config.Config) { cfg.ProjectID = "2" },
+			check:      "Project #2",
+			wantStatus: StatusFail,
+			wantDetail: "project 2 not found for acme",
+			wantFailed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, cfg := setupDoctorTest(t)
+			if tt.setup != nil {
+				tt.setup(client, cfg)
+			}
+
+			report := Run(context.Background(), client, cfg)
+			check := findCheck(t, report, tt.check)
+			if check.Status != tt.wantStatus || check.Detail != tt.wantDetail {
+				t.Errorf("%s = %s %q, want %s %q", tt.check, check.Status, check.Detail, tt.wantStatus, tt.wantDetail)
+			}
+			if got := report.Failed(); got != tt.wantFailed {
+				t.Errorf("Failed() = %d, want %d: %+v", got, tt.wantFailed, report.Checks)
+			}
+		})
+	}
+}
+
+func TestCheckIssueTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		types      map[string]bool // Name -> enabled; nil keeps the template types
+		disable    bool            // Issue types API unavailable
+		user       bool            // Context owned by a user
+		wantStatus Status
+		wantDetail string
+	}{
+		{
+			name:       "template types enabled",
+			wantStatus: StatusOK,
+			wantDetail: "5 enabled, including Epic, User Story, Task, Bug, Feature",
+		},
+		{
+			name:       "issue types unavailable",
+			disable:    true,
+			wantStatus: StatusWarn,
+			wantDetail: "not enabled for acme",
+		},
+		{
+			name:       "none enabled",
+			types:      map[string]bool{"Epic": false, "Task": false},
+			wantStatus: StatusWarn,
+			wantDetail: "none of the 2 issue types of acme are enabled",
+		},
+		{
+			name:       "no issue types",
+			types:      map[string]bool{},
+			wantStatus: StatusWarn,
+			wantDetail: "missing Epic, User Story, Task, Bug, Feature",
+		},
+		{
+			name:       "missing and disabled template types",
+			types:      map[string]bool{"Epic": true, "User Story": false, "Task": true, "Spike": true},
+			wantStatus: StatusWarn,
+			wantDetail: "missing Bug, Feature; disabled User Story",
+		},
+		{
+			name:       "user project",
+			user:       true,
+			wantStatus: StatusOK,
+			wantDetail: "skipped, issue types are an organization feature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.New("me")
+			client.AddOrganization("acme", "Acme")
+			types := tt.types
+			if types == nil {
+				types = make(map[string]bool)
+				for _, name := range templateIssueTypes {
+					types[name] = true
+				}
+			}
+			for name, enabled := range types {
+				client.AddIssueType("acme", name, enabled)
+			}
+			if tt.disable {
+				client.DisableIssueTypes("acme")
+			}
+
+			cfg := &config.Config{OwnerType: config.OwnerTypeOrg, Owner: "acme"}
+			if tt.user {
+				cfg.OwnerType = config.OwnerTypeUser
+			}
+
+			report := &Report{}
+			checkIssueTypes(context.Background(), client, cfg, report)
+			check := findCheck(t, report, "Issue types")
+			if check.Status != tt.wantStatus || check.Detail != tt.wantDetail {
+				t.Errorf("got %s %q, want %s %q", check.Status, check.Detail, tt.wantStatus, tt.wantDetail)
+			}
+			if check.Status == StatusWarn && check.Hint == "" {
+				t.Errorf("warning without a hint")
+			}
+		})
+	}
+}
@@ -11,9 +11,8 @@ import (
 	"github.com/Zytera/gh-project-management/internal/templates"
 )
 
-// assignIssueToProject assigns an issue to the configured project
-// Returns the project item ID
-func assignIssueToProject(ctx context.Context, cfg *config.Config, issue *gh.Issue) (string, error) {
+// getProjectNodeID resolves the node ID of the configured project
+func getProjectNodeID(ctx context.Context, client gh.Client, cfg *config.Config) (string, error) {
 	// Parse project number
 	projectNumber, err := strconv.Atoi(cfg.ProjectID)
 	if err != nil {
@@ -23,16 +22,26 @@ func assignIssueToProject(ctx context.Context, cfg *config.Config, issue *gh.Iss
 	// Get project node ID
 	var projectNodeID string
 	if cfg.OwnerType == config.OwnerTypeOrg {
-		projectNodeID, err = gh.GetProjectNodeID(ctx, cfg.Owner, projectNumber)
+		projectNodeID, err = client.GetProjectNodeID(ctx, cfg.Owner, projectNumber)
 	} else {
-		projectNodeID, err = gh.GetUserProjectNodeID(ctx, projectNumber)
+		projectNodeID, err = client.GetUserProjectNodeID(ctx, projectNumber)
 	}
 	if err != nil {
 		return "", fmt.Errorf("failed to get project node ID: %w", err)
 	}
+	return projectNodeID, nil
+}
+
+// assignIssueToProject assigns an issue to the configured project
+// Returns the project item ID
+func assignIssueToProject(ctx context.Context, client gh.Client, cfg *config.Config, issue *gh.Issue) (string, error) {
+	projectNodeID, err := getProjectNodeID(ctx, client, cfg)
+	if err != nil {
+		return "", err
+	}
 
 	// Add issue to project and get the project item ID
-	projectItemID, err := gh.AddIssueToProject(ctx, projectNodeID, issue.ID)
+	projectItemID, err := client.AddIssueToProject(ctx, projectNodeID, issue.ID)
 	if err != nil {
 		return "", fmt.Errorf("failed to add issue to project: %w", err)
 	}
@@ -44,23 +53,30 @@ func assignIssueToProject(ctx context.Context, cfg *config.Config, issue *gh.Iss
 type CreateDynamicIssueParams struct {
 	Config    *config.Config
 	IssueType string
-	Title     string
+	Title     string // Rendered from the template's title pattern if empty, see RenderTitle
+	Team      string // Value of the {{team}} title placeholder
 	Fields    map[string]string
+	Key       string   // Optional: stored in the body so the issue can be found again, see templates.KeyMarker
+	Labels    []string // Added to the template's labels
+	Assignees []string // Logins; "@me" is the current user
+	Milestone string   // Title or number of an open milestone
 }
 
 // CreateDynamicIssueResult contains the result of creating an issue
 type CreateDynamicIssueResult struct {
-	Issue         *gh.Issue
-	ProjectItemID string
+	Issue          *gh.Issue
+	ProjectItemID  string
+	TemplateSource string
+	Labels         []string // Names of the labels set on the issue
+	Warnings       []error  // Problems that did not stop the issue from being created
 }
 
-// CreateDynamicIssue creates an issue using a dynamic template (from repo or default)
-func CreateDynamicIssue(ctx context.Context, params CreateDynamicIssueParams) (*CreateDynamicIssueResult, error) {
+// CreateDynamicIssue creates an issue using a dynamic template (from repo or default).
+// If the issue is created but can't be added to the project, the result holds
+// the issue along with the error.
+func CreateDynamicIssue(ctx context.Context, client gh.Client, params CreateDynamicIssueParams) (*CreateDynamicIssueResult, error) {
 	// Get template (from repo or default)
-	var template *templates.IssueTemplate
-	var err error
-
-	template, _, err = GetTemplate(ctx, params.Config.Owner, params.Config.DefaultRepo, params.IssueType)
+	template, templateSource, err := GetTemplate(ctx, client, params.Config.Owner, params.Config.DefaultRepo, params.IssueType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get template for type %s: %w", params.IssueType, err)
 	}
@@ -70,46 +86,183 @@ func CreateDynamicIssue(ctx context.Context, params CreateDynamicIssueParams) (*
 		return nil, fmt.Errorf("field validation failed: %w", err)
 	}
 
+	title := params.Title
+	if title == "" {
+		if title, err = RenderTitle(template, params.IssueType, params.Team, params.Fields); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build issue body from template
 	body, err := templates.BuildBodyFromTemplate(template, params.Fields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build issue body: %w", err)
 	}
+	body = templates.WithKey(body, params.Key)
 
 	// Map issue type to GitHub issue type name
 	issueTypeName := mapIssueTypeToGitHubType(params.IssueType)
 
 	// Ensure issue type exists and get its ID
 	var issueTypeID string
+	var warnings []error
 	if params.Config.OwnerType == config.OwnerTypeOrg && issueTypeName != "" {
 		// EnsureIssueType will create the type if it doesn't exist
-		issueTypeConfig, err := gh.EnsureIssueType(ctx, params.Config.Owner, issueTypeName, fmt.Sprintf("%s issue type", issueTypeName))
+		issueTypeConfig, err := gh.EnsureIssueType(ctx, client, params.Config.Owner, issueTypeName, fmt.Sprintf("%s issue type", issueTypeName))
 		if err != nil {
-			// Log warning but continue - issue types might not be available for this org
-			fmt.Printf("⚠️  Warning: Could not ensure issue type '%s': %v\n", issueTypeName, err)
+			// Continue - the issue is still useful without a type
+			warnings = append(warnings, fmt.Errorf("could not ensure issue type '%s': %w", issueTypeName, err))
 		} else if issueTypeConfig != nil {
 			issueTypeID = issueTypeConfig.ID
 		}
 	}
 
-	// Create issue with issue type
-	issue, err := gh.CreateIssue(ctx, params.Config.Owner, params.Config.DefaultRepo, params.Title, body, issueTypeID)
+	opts := gh.CreateIssueOptions{IssueTypeID: issueTypeID}
+
+	// Resolve labels, assignees and milestone in the default repository
+	labels, labelWarnings, err := resolveLabels(ctx, client, params.Config, template.Labels, params.Labels, &opts)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, labelWarnings...)
+
+	if opts.AssigneeIDs, err = resolveAssignees(ctx, client, params.Assignees); err != nil {
+		return nil, err
+	}
+
+	if params.Milestone != "" {
+		milestone, err := gh.FindMilestone(ctx, client, params.Config.Owner, params.Config.DefaultRepo, params.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		opts.MilestoneID = milestone.ID
+	}
+
+	// Create issue with issue type, labels, assignees and milestone
+	issue, err := client.CreateIssue(ctx, params.Config.Owner, params.Config.DefaultRepo, title, body, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create issue: %w", err)
 	}
 
 	// Assign to project and get project item ID
-	projectItemID, err := assignIssueToProject(ctx, params.Config, issue)
+	projectItemID, err := assignIssueToProject(ctx, client, params.Config, issue)
 	if err != nil {
-		return nil, fmt.Errorf("failed to assign issue to project: %w", err)
+		// The issue exists; return it so callers can report or remove it
+		partial := &CreateDynamicIssueResult{Issue: issue, TemplateSource: templateSource, Labels: labels, Warnings: warnings}
+		return partial, fmt.Errorf("failed to assign issue to project: %w", err)
 	}
 
 	return &CreateDynamicIssueResult{
-		Issue:         issue,
-		ProjectItemID: projectItemID,
+		Issue:          issue,
+		ProjectItemID:  projectItemID,
+		TemplateSource: templateSource,
+		Labels:         labels,
+		Warnings:       warnings,
 	}, nil
 }
 
+// TitleValues returns the values of the title placeholders: the template
+// fields, the GitHub type name of issueType and team. A field with the ID
+// "type" or "team" takes precedence.
+func TitleValues(issueType, team string, fields map[string]string) map[string]string {
+	values := map[string]string{
+		templates.TitleKeyType: mapIssueTypeToGitHubType(issueType),
+		templates.TitleKeyTeam: team,
+	}
+	for id, value := range fields {
+		values[id] = value
+	}
+	return values
+}
+
+// RenderTitle renders the title pattern of template for an issue that has no
+// explicit title. It fails if the template has no pattern or a placeholder
+// has no value.
+func RenderTitle(template *templates.IssueTemplate, issueType, team string, fields map[string]string) (string, error) {
+	if !template.HasTitlePattern() {
+		return "", fmt.Errorf("a title is required: the %s template has no title pattern", issueType)
+	}
+
+	title, missing := template.RenderTitle(TitleValues(issueType, team, template.WithDefaults(fields)))
+	if len(missing) > 0 {
+		return "", fmt.Errorf("title pattern '%s' needs a value for %s: set them or pass a title", template.Title, strings.Join(missing, ", "))
+	}
+	return strings.TrimSpace(title), nil
+}
+
+// resolveLabels resolves the template's labels and the requested ones to IDs
+// in the default repository and stores them in opts. Template labels the
+// repository doesn't have are skipped with a warning, like GitHub issue forms
+// do; requested labels must exist. It returns the names of the labels set.
+func resolveLabels(ctx context.Context, client gh.Client, cfg *config.Config, templateLabels, requested []string, opts *gh.CreateIssueOptions) ([]string, []error, error) {
+	var names []string
+	fromTemplate := make(map[string]bool)
+	for _, name := range templateLabels {
+		if name = strings.TrimSpace(name); name != "" && !containsFold(names, name) {
+			names = append(names, name)
+			fromTemplate[strings.ToLower(name)] = true
+		}
+	}
+	for _, name := range requested {
+		if name = strings.TrimSpace(name); name != "" && !containsFold(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil, nil
+	}
+
+	ids, missing, err := gh.ResolveLabels(ctx, client, cfg.Owner, cfg.DefaultRepo, names)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve labels: %w", err)
+	}
+
+	var warnings []error
+	for _, name := range missing {
+		if !fromTemplate[strings.ToLower(name)] {
+			return nil, nil, fmt.Errorf("label '%s' not found in %s/%s", name, cfg.Owner, cfg.DefaultRepo)
+		}
+		warnings = append(warnings, fmt.Errorf("template label '%s' doesn't exist in %s/%s, skipped", name, cfg.Owner, cfg.DefaultRepo))
+	}
+
+	var applied []string
+	for _, name := range names {
+		if !containsFold(missing, name) {
+			applied = append(applied, name)
+		}
+	}
+	opts.LabelIDs = ids
+	return applied, warnings, nil
+}
+
+// resolveAssignees maps logins to user node IDs; "@me" is the current user
+func resolveAssignees(ctx context.Context, client gh.Client, logins []string) ([]string, error) {
+	var ids []string
+	for _, login := range logins {
+		if login == "@me" {
+			var err error
+			if login, err = client.GetCurrentUser(); err != nil {
+				return nil, fmt.Errorf("failed to get current user: %w", err)
+			}
+		}
+		id, err := client.GetUserNodeID(ctx, login)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assignee: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
 // mapIssueTypeToGitHubType maps template issue type to GitHub issue type name
 func mapIssueTypeToGitHubType(issueType string) string {
 	switch strings.ToLower(issueType) {
@@ -131,3 +284,15 @@ func mapIssueTypeToGitHubType(issueType string) string {
 		return ""
 	}
 }
+
+// mapGitHubTypeToIssueType maps a GitHub issue type name back to its template type
+func mapGitHubTypeToIssueType(githubType string) string {
+	switch githubType {
+	case "":
+		return ""
+	case "User Story":
+		return "user_story"
+	default:
+		return strings.ReplaceAll(strings.ToLower(githubType), " ", "_")
+	}
+}
@@ -0,0 +1,271 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+)
+
+// CreateDraftIssueParams contains parameters for adding a draft issue to the project
+type CreateDraftIssueParams struct {
+	Config    *config.Config
+	IssueType string
+	Title     string // Rendered from the template's title pattern if empty, see RenderTitle
+	Team      string // Value of the {{team}} title placeholder
+	Fields    map[string]string
+	Assignees []string // Logins; "@me" is the current user
+
+	ProjectFields map[string]string // Single-select field name -> option, e.g. "Team": "Backend"
+}
+
+// CreateDraftIssueResult contains the result of adding a draft issue
+type CreateDraftIssueResult struct {
+	ProjectItemID  string
+	Title          string
+	TemplateSource string
+	Fields         map[string]string // Project fields that were set
+	Warnings       []error           // Project fields that could not be set
+}
+
+// CreateDraftIssue adds a draft issue to the configured project, with the
+// body rendered from the template like CreateDynamicIssue does. Drafts belong
+// to no repository, so they have no issue type or labels; the template type
+// is stored in the body (see templates.TypeMarker) for PromoteDraft.
+func CreateDraftIssue(ctx context.Context, client gh.Client, params CreateDraftIssueParams) (*CreateDraftIssueResult, error) {
+	template, templateSource, err := GetTemplate(ctx, client, params.Config.Owner, params.Config.DefaultRepo, params.IssueType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template for type %s: %w", params.IssueType, err)
+	}
+
+	if err := templates.ValidateFields(template, params.Fields); err != nil {
+		return nil, fmt.Errorf("field validation failed: %w", err)
+	}
+
+	title := params.Title
+	if title == "" {
+		if title, err = RenderTitle(template, params.IssueType, params.Team, params.Fields); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := templates.BuildBodyFromTemplate(template, params.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build issue body: %w", err)
+	}
+	body = templates.WithType(body, params.IssueType)
+
+	assigneeIDs, err := resolveAssignees(ctx, client, params.Assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	projectNodeID, err := getProjectNodeID(ctx, client, params.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	itemID, err := client.AddDraftIssueToProject(ctx, projectNodeID, title, body, assigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CreateDraftIssueResult{
+		ProjectItemID:  itemID,
+		Title:          title,
+		TemplateSource: templateSource,
+		Fields:         make(map[string]string),
+	}
+
+	// The draft exists; a field that can't be set is only a warning
+	names := make([]string, 0, len(params.ProjectFields))
+	for name := range params.ProjectFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := params.ProjectFields[name]
+		field, optionID, err := gh.FindFieldOption(ctx, client, projectNodeID, name, value)
+		if err == nil {
+			err = client.UpdateProjectItemField(ctx, projectNodeID, itemID, field.ID, optionID)
+		}
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Errorf("could not set %s: %w", name, err))
+			continue
+		}
+		result.Fields[name] = value
+	}
+
+	return result, nil
+}
+
+// ListDrafts lists the draft issues of the configured project
+func ListDrafts(ctx context.Context, client gh.Client, cfg *config.Config) ([]gh.DraftIssue, error) {
+	projectNodeID, err := getProjectNodeID(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.ListDraftIssues(ctx, projectNodeID)
+}
+
+// FindDraft finds a draft issue by project item ID, or by title ignoring case
+func FindDraft(drafts []gh.DraftIssue, draft string) (*gh.DraftIssue, error) {
+	for i := range drafts {
+		if drafts[i].ID == draft {
+			return &drafts[i], nil
+		}
+	}
+
+	var matches []*gh.DraftIssue
+	for i := range drafts {
+		if strings.EqualFold(strings.TrimSpace(drafts[i].Title), strings.TrimSpace(draft)) {
+			matches = append(matches, &drafts[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("draft issue '%s' not found in the project", draft)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = match.ID
+		}
+		return nil, fmt.Errorf("%d draft issues are titled '%s': use one of their item IDs (%s)", len(matches), draft, strings.Join(ids, ", "))
+	}
+}
+
+// PromoteDraftParams contains parameters for converting a draft issue to an issue
+type PromoteDraftParams struct {
+	Config    *config.Config
+	Draft     string // Project item ID or title of the draft issue
+	IssueType string // Template type; read from the draft's body if empty
+	Repo      string // Target repository; if empty, the team repository of the draft's Team field, or DefaultRepo
+}
+
+// PromoteDraftResult contains the result of promoting a draft issue
+type PromoteDraftResult struct {
+	Issue         *gh.Issue
+	Owner         string
+	Repo          string
+	ProjectItemID string
+	IssueType     string
+	Labels        []string          // Names of the template labels set on the issue
+	Fields        map[string]string // Project field values kept from the draft
+	Warnings      []error           // Problems that did not stop the promotion
+}
+
+// PromoteDraft converts a draft issue of the configured project into an issue
+// in a repository. The project item and its field values are kept. Once the
+// issue exists, setting the issue type and the template labels and removing
+// the type marker from the body only produce warnings.
+func PromoteDraft(ctx context.Context, client gh.Client, params PromoteDraftParams) (*PromoteDraftResult, error) {
+	cfg := params.Config
+
+	drafts, err := ListDrafts(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := FindDraft(drafts, params.Draft)
+	if err != nil {
+		return nil, err
+	}
+
+	storedType, body := templates.FindType(draft.Body)
+	issueType := params.IssueType
+	if issueType == "" {
+		issueType = storedType
+	}
+	if issueType == "" {
+		return nil, fmt.Errorf("draft issue '%s' has no stored type: pass the issue type", draft.Title)
+	}
+
+	var warnings []error
+	repo := params.Repo
+	if repo == "" {
+		if team := draft.Fields["Team"]; team != "" {
+			if teamRepo, ok := cfg.TeamRepos[team]; ok {
+				repo = teamRepo
+			} else {
+				warnings = append(warnings, fmt.Errorf("no repository configured for team '%s', promoting to %s/%s", team, cfg.Owner, cfg.DefaultRepo))
+			}
+		}
+	}
+	if repo == "" {
+		repo = cfg.DefaultRepo
+	}
+
+	// Check the draft against the template before it becomes an issue
+	template, _, err := GetTemplate(ctx, client, cfg.Owner, cfg.DefaultRepo, issueType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template for type %s: %w", issueType, err)
+	}
+	if parsed := templates.ParseBody(template, body); len(parsed.Missing) > 0 {
+		warnings = append(warnings, fmt.Errorf("draft issue is missing required fields: %s", strings.Join(parsed.Missing, ", ")))
+	}
+
+	repoID, err := client.GetRepositoryNodeID(ctx, cfg.Owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository node ID: %w", err)
+	}
+
+	issue, err := client.ConvertDraftIssue(ctx, draft.ID, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PromoteDraftResult{
+		Issue:         issue,
+		Owner:         cfg.Owner,
+		Repo:          repo,
+		ProjectItemID: draft.ID,
+		IssueType:     issueType,
+		Fields:        draft.Fields,
+	}
+
+	if body != draft.Body {
+		if err := client.UpdateIssue(ctx, issue.ID, nil, &body); err != nil {
+			warnings = append(warnings, fmt.Errorf("could not remove the type marker from the body: %w", err))
+		} else {
+			issue.Body = body
+		}
+	}
+
+	issueTypeName := mapIssueTypeToGitHubType(issueType)
+	if cfg.OwnerType == config.OwnerTypeOrg && issueTypeName != "" {
+		issueTypeConfig, err := gh.EnsureIssueType(ctx, client, cfg.Owner, issueTypeName, fmt.Sprintf("%s issue type", issueTypeName))
+		if err == nil && issueTypeConfig != nil {
+			err = client.SetIssueType(ctx, issue.ID, issueTypeConfig.ID)
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Errorf("could not set issue type '%s': %w", issueTypeName, err))
+		}
+	}
+
+	// Template labels the repository doesn't have are skipped, like on creation
+	if len(template.Labels) > 0 {
+		ids, missing, err := gh.ResolveLabels(ctx, client, cfg.Owner, repo, template.Labels)
+		if err == nil {
+			err = client.AddLabelsToIssue(ctx, issue.ID, ids)
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Errorf("could not add template labels: %w", err))
+		} else {
+			for _, name := range template.Labels {
+				if containsFold(missing, name) {
+					warnings = append(warnings, fmt.Errorf("template label '%s' doesn't exist in %s/%s, skipped", name, cfg.Owner, repo))
+				} else {
+					result.Labels = append(result.Labels, name)
+				}
+			}
+		}
+	}
+
+	result.Warnings = warnings
+	return result, nil
+}
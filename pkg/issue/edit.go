@@ -0,0 +1,138 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+)
+
+// EditIssueParams contains parameters for editing an existing issue
+type EditIssueParams struct {
+	Config    *config.Config
+	Ref       string            // Issue reference ("#12", "owner/repo#12" or a URL)
+	IssueType string            // Template type; defaults to the one matching the issue's type
+	Title     string            // New title; "" keeps the current one
+	Fields    map[string]string // Template field ID -> new value; "" removes an optional field
+}
+
+// EditIssueResult contains the result of editing an issue
+type EditIssueResult struct {
+	Issue         *gh.IssueDetails // The issue before the edit
+	IssueType     string           // Template type the body was parsed with
+	ChangedFields []string         // IDs of fields whose value changed, sorted
+	TitleChanged  bool
+	Unknown       []templates.Section // Sections kept as-is because the template doesn't know them
+	Missing       []string            // Required fields still without a value after the edit
+}
+
+// Changed reports whether the edit changed anything
+func (r *EditIssueResult) Changed() bool {
+	return r.TitleChanged || len(r.ChangedFields) > 0
+}
+
+// EditIssue parses an issue's body against its template, replaces the given
+// fields after validating them and updates the title and body
+func EditIssue(ctx context.Context, client gh.Client, params EditIssueParams) (*EditIssueResult, error) {
+	owner, repo, number, err := gh.ParseIssueReference(params.Ref, params.Config.Host, params.Config.Owner, params.Config.DefaultRepo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue reference: %w", err)
+	}
+
+	details, err := client.GetIssueDetails(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	issueType := params.IssueType
+	if issueType == "" {
+		issueType = mapGitHubTypeToIssueType(details.IssueType)
+	}
+	if issueType == "" {
+		return nil, fmt.Errorf("issue %s/%s#%d has no issue type, use --type to choose its template", owner, repo, number)
+	}
+
+	// Templates live in the default repository, even for transferred issues
+	template, _, err := GetTemplate(ctx, client, params.Config.Owner, params.Config.DefaultRepo, issueType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template for type %s: %w", issueType, err)
+	}
+
+	inputs := make(map[string]templates.BodyField)
+	for _, field := range template.GetAllInputFields() {
+		inputs[field.ID] = field
+	}
+
+	parsed := templates.ParseBody(template, details.Body)
+
+	result := &EditIssueResult{
+		Issue:     details,
+		IssueType: issueType,
+		Unknown:   parsed.Unknown,
+	}
+
+	ids := make([]string, 0, len(params.Fields))
+	for id := range params.Fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Report the violations of every field at once
+	var violations []string
+	for _, id := range ids {
+		field, ok := inputs[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown field '%s' for type %s (available: %s)", id, issueType, strings.Join(fieldIDs(template), ", "))
+		}
+		violations = append(violations, templates.Violations(field.ValidateFieldValue(params.Fields[id]))...)
+	}
+	if len(violations) > 0 {
+		return nil, &templates.ValidationError{Violations: violations}
+	}
+
+	changes := make(map[string]string)
+	for _, id := range ids {
+		value := params.Fields[id]
+		if parsed.Fields[id] != value {
+			changes[id] = value
+			result.ChangedFields = append(result.ChangedFields, id)
+		}
+	}
+
+	for _, id := range parsed.Missing {
+		if changes[id] == "" {
+			result.Missing = append(result.Missing, id)
+		}
+	}
+
+	var title, body *string
+	if params.Title != "" && params.Title != details.Title {
+		title = &params.Title
+		result.TitleChanged = true
+	}
+	if len(changes) > 0 {
+		updated := templates.UpdateBody(template, parsed, changes)
+		body = &updated
+	}
+
+	if !result.Changed() {
+		return result, nil
+	}
+
+	if err := client.UpdateIssue(ctx, details.ID, title, body); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func fieldIDs(template *templates.IssueTemplate) []string {
+	var ids []string
+	for _, field := range template.GetAllInputFields() {
+		ids = append(ids, field.ID)
+	}
+	return ids
+}
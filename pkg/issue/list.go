@@ -0,0 +1,226 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+)
+
+// SortKeys are the values accepted by ListParams.Sort
+var SortKeys = []string{"number", "title", "repo", "type", "team", "priority", "status", "updated"}
+
+// priorityRank orders Priority options from most to least urgent
+var priorityRank = map[string]int{
+	"Critical": 0,
+	"High":     1,
+	"Medium":   2,
+	"Low":      3,
+}
+
+// ListParams filters, sorts and limits the issues of the configured project.
+// Empty filters match every issue; matching ignores case.
+type ListParams struct {
+	Config   *config.Config
+	Team     string
+	Priority string
+	Status   string
+	Type     string // Issue type or template type (story matches "User Story")
+	Repo     string // Repository name, or owner/name
+	State    string // open (default), closed or all
+	Assignee string // Login, or @me for the authenticated user
+	Parent   string // Issue reference of the parent
+	Sort     string // One of SortKeys; defaults to repo then number
+	Reverse  bool
+	Limit    int // 0 lists all matching issues
+}
+
+// ListIssues returns the project's issues that match the filters
+func ListIssues(ctx context.Context, client gh.Client, params ListParams) ([]gh.ProjectItem, error) {
+	state := strings.ToUpper(params.State)
+	switch state {
+	case "":
+		state = "OPEN"
+	case "OPEN", "CLOSED", "ALL":
+	default:
+		return nil, fmt.Errorf("invalid state '%s': expected open, closed or all", params.State)
+	}
+
+	less, err := sortFunc(params.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	assignee := params.Assignee
+	if assignee == "@me" {
+		assignee, err = client.GetCurrentUser()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+	}
+
+	parent := ""
+	if params.Parent != "" {
+		owner, repo, number, err := gh.ParseIssueReference(params.Parent, params.Config.Host, params.Config.Owner, params.Config.DefaultRepo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent reference: %w", err)
+		}
+		parent = fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	}
+
+	projectNodeID, err := getProjectNodeID(ctx, client, params.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := client.ListProjectItems(ctx, projectNodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project items: %w", err)
+	}
+
+	matches := make([]gh.ProjectItem, 0, len(items))
+	for _, item := range items {
+		if state != "ALL" && item.State != state {
+			continue
+		}
+		if !matchesField(item.Fields["Team"], params.Team) ||
+			!matchesField(item.Fields["Priority"], params.Priority) ||
+			!matchesField(item.Fields["Status"], params.Status) ||
+			!matchesType(item, params.Type) ||
+			!matchesRepo(item, params.Repo) ||
+			!matchesAssignee(item, assignee) ||
+			!matchesField(item.Parent, parent) {
+			continue
+		}
+		matches = append(matches, item)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if params.Reverse {
+			return less(matches[j], matches[i])
+		}
+		return less(matches[i], matches[j])
+	})
+
+	if params.Limit > 0 && len(matches) > params.Limit {
+		matches = matches[:params.Limit]
+	}
+	return matches, nil
+}
+
+func matchesField(value, filter string) bool {
+	return filter == "" || strings.EqualFold(value, filter)
+}
+
+// matchesType checks the issue type, falling back to a "Type" project field
+func matchesType(item gh.ProjectItem, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	itemType := item.IssueType
+	if itemType == "" {
+		itemType = item.Fields["Type"]
+	}
+	return strings.EqualFold(itemType, filter) || strings.EqualFold(itemType, mapIssueTypeToGitHubType(filter))
+}
+
+func matchesRepo(item gh.ProjectItem, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if strings.Contains(filter, "/") {
+		return strings.EqualFold(item.Owner+"/"+item.Repo, filter)
+	}
+	return strings.EqualFold(item.Repo, filter)
+}
+
+func matchesAssignee(item gh.ProjectItem, login string) bool {
+	if login == "" {
+		return true
+	}
+	for _, assignee := range item.Assignees {
+		if strings.EqualFold(assignee, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortFunc returns the ordering for a sort key
+func sortFunc(key string) (func(a, b gh.ProjectItem) bool, error) {
+	byRepoAndNumber := func(a, b gh.ProjectItem) bool {
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Issue.Number < b.Issue.Number
+	}
+	// Items without a value sort last
+	byField := func(name string) func(a, b gh.ProjectItem) bool {
+		return func(a, b gh.ProjectItem) bool {
+			av, bv := a.Fields[name], b.Fields[name]
+			if av != bv {
+				return bv == "" || (av != "" && av < bv)
+			}
+			return byRepoAndNumber(a, b)
+		}
+	}
+
+	switch key {
+	case "", "repo":
+		return byRepoAndNumber, nil
+	case "number":
+		return func(a, b gh.ProjectItem) bool {
+			if a.Issue.Number != b.Issue.Number {
+				return a.Issue.Number < b.Issue.Number
+			}
+			return a.Repo < b.Repo
+		}, nil
+	case "title":
+		return func(a, b gh.ProjectItem) bool {
+			at, bt := strings.ToLower(a.Issue.Title), strings.ToLower(b.Issue.Title)
+			if at != bt {
+				return at < bt
+			}
+			return byRepoAndNumber(a, b)
+		}, nil
+	case "type":
+		return func(a, b gh.ProjectItem) bool {
+			if a.IssueType != b.IssueType {
+				return b.IssueType == "" || (a.IssueType != "" && a.IssueType < b.IssueType)
+			}
+			return byRepoAndNumber(a, b)
+		}, nil
+	case "team":
+		return byField("Team"), nil
+	case "status":
+		return byField("Status"), nil
+	case "priority":
+		return func(a, b gh.ProjectItem) bool {
+			ar, br := rank(a.Fields["Priority"]), rank(b.Fields["Priority"])
+			if ar != br {
+				return ar < br
+			}
+			return byRepoAndNumber(a, b)
+		}, nil
+	case "updated":
+		// Most recently updated first
+		return func(a, b gh.ProjectItem) bool {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid sort key '%s': expected one of %s", key, strings.Join(SortKeys, ", "))
+}
+
+// rank returns the position of a priority, with unknown and unset priorities last
+func rank(priority string) int {
+	if r, ok := priorityRank[priority]; ok {
+		return r
+	}
+	if priority == "" {
+		return len(priorityRank) + 1
+	}
+	return len(priorityRank)
+}
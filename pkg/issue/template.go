@@ -3,12 +3,15 @@ package issue
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Zytera/gh-project-management/internal/gh"
 	"github.com/Zytera/gh-project-management/internal/templates"
 )
 
-func GetTemplate(ctx context.Context, owner string, repo string, issueType string) (*templates.IssueTemplate, string, error) {
+// GetTemplate returns the template for an issue type and a description of where it came from:
+// the local checkout of repo, the repository on GitHub, or the embedded defaults
+func GetTemplate(ctx context.Context, client gh.Client, owner string, repo string, issueType string) (*templates.IssueTemplate, string, error) {
 
 	var template *templates.IssueTemplate
 	var templateSource string
@@ -16,11 +19,10 @@ func GetTemplate(ctx context.Context, owner string, repo string, issueType strin
 
 	template, templateSource, err = templates.GetTemplateFromLocalRepo(ctx, owner, repo, issueType)
 	if err == nil {
-		fmt.Println("Template found in local repository")
-		return template, templateSource, nil
+		return template, fmt.Sprintf("local repository (%s)", strings.TrimPrefix(templateSource, "/")), nil
 	}
 
-	template, _, err = gh.GetTemplateFromRepo(ctx, owner, repo, issueType)
+	template, _, err = client.GetTemplateFromRepo(ctx, owner, repo, issueType)
 	if err == nil && template != nil {
 		return template, fmt.Sprintf("repository (.github/ISSUE_TEMPLATE/%s)", templates.GetTemplateFileName(issueType)), nil
 	} else {
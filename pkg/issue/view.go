@@ -0,0 +1,40 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+)
+
+// IssueView is an issue with its relationships and its item in the configured project
+type IssueView struct {
+	*gh.IssueDetails
+	ProjectItem *gh.ProjectFieldValues // nil if the issue is not in the configured project
+}
+
+// ViewIssue fetches an issue by reference ("#12", "owner/repo#12" or a URL)
+// together with its field values in the configured project
+func ViewIssue(ctx context.Context, client gh.Client, cfg *config.Config, ref string) (*IssueView, error) {
+	owner, repo, number, err := gh.ParseIssueReference(ref, cfg.Host, cfg.Owner, cfg.DefaultRepo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue reference: %w", err)
+	}
+
+	details, err := client.GetIssueDetails(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	projectNodeID, err := getProjectNodeID(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &IssueView{IssueDetails: details}
+	if item, ok := details.ProjectFields(projectNodeID); ok {
+		view.ProjectItem = item
+	}
+	return view, nil
+}
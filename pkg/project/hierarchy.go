@@ -0,0 +1,90 @@
+package project
+
+import (
+	"context"
+)
+
+// Node is an issue to create together with its sub-issues.
+// The Parent of each node's params is set by CreateHierarchy.
+type Node struct {
+	CreateIssueParams
+	Children []Node
+}
+
+// HierarchyResult is the outcome of creating a node and its children
+type HierarchyResult struct {
+	*CreateIssueResult
+	Children []*HierarchyResult
+}
+
+// Walk calls fn for r and every descendant, parents before children
+func (r *HierarchyResult) Walk(fn func(*HierarchyResult)) {
+	fn(r)
+	for _, child := range r.Children {
+		child.Walk(fn)
+	}
+}
+
+// AllWarnings returns the warnings of r and all its descendants
+func (r *HierarchyResult) AllWarnings() []error {
+	var warnings []error
+	r.Walk(func(node *HierarchyResult) {
+		warnings = append(warnings, node.Warnings...)
+	})
+	return warnings
+}
+
+// CreateHierarchy creates root and its descendants, linking each issue to its
+// parent. Sub-issues must share their parent's repository, so transfers
+// requested by any node run only after the whole tree has been created and linked.
+//
+// If an issue can't be created, creation stops, no issue is transferred and
+// the error is returned with the partial result of what was created.
+func (s *Service) CreateHierarchy(ctx context.Context, root Node) (*HierarchyResult, error) {
+	result, err := s.createNode(ctx, root, IssueRef{})
+	if err != nil {
+		// Leave a partial tree in one repository so it can be completed by hand
+		return result, err
+	}
+
+	s.transferNodes(ctx, root, result)
+	return result, nil
+}
+
+// createNode creates an issue and its children depth first. It returns a nil
+// result only if the node's issue wasn't created.
+func (s *Service) createNode(ctx context.Context, node Node, parent IssueRef) (*HierarchyResult, error) {
+	params := node.CreateIssueParams
+	params.Parent = parent
+
+	created, err := s.createIssue(ctx, params, nil)
+	if err != nil {
+		if created != nil {
+			// Created but not added to the project
+			return &HierarchyResult{CreateIssueResult: created}, err
+		}
+		return nil, err
+	}
+
+	result := &HierarchyResult{CreateIssueResult: created}
+	for _, child := range node.Children {
+		childResult, err := s.createNode(ctx, child, created.Ref)
+		if childResult != nil {
+			result.Children = append(result.Children, childResult)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// transferNodes transfers every created node that requested it, parents first
+func (s *Service) transferNodes(ctx context.Context, node Node, result *HierarchyResult) {
+	if node.Transfer {
+		s.transferToTeam(ctx, result.CreateIssueResult, node.ProjectFields["Team"])
+	}
+	for i, child := range result.Children {
+		s.transferNodes(ctx, node.Children[i], child)
+	}
+}
@@ -0,0 +1,333 @@
+package project
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ImportRow is an issue to create, read from a row of a CSV or JSON file
+type ImportRow struct {
+	Row       int    // 1-based row number, not counting the CSV header
+	Key       string // Optional: lets later rows refer to this one as parent or dependency
+	Type      string
+	Title     string // Rendered from the template's title pattern if empty
+	Team      string
+	Priority  string
+	Parent    string            // Issue reference or key of an earlier row
+	DependsOn []string          // Issue references or keys of earlier rows
+	Fields    map[string]string // Template field ID -> value, empty cells left out
+}
+
+// Columns with a meaning of their own; every other column is a template field ID
+const (
+	columnKey       = "key"
+	columnType      = "type"
+	columnTitle     = "title"
+	columnTeam      = "team"
+	columnPriority  = "priority"
+	columnParent    = "parent"
+	columnDependsOn = "depends_on"
+)
+
+// ReadImportFile reads issues from a CSV file with a header row, or from a
+// JSON array of objects. The format is chosen by the file extension.
+func ReadImportFile(path string) ([]ImportRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadImportCSV(file)
+	case ".json":
+		return ReadImportJSON(file)
+	default:
+		return nil, fmt.Errorf("unsupported import file '%s': use a .csv or .json file", path)
+	}
+}
+
+// ReadImportCSV reads issues from CSV with a header row naming the columns
+func ReadImportCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("import file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		values := make(map[string]string)
+		for i, column := range header {
+			if i < len(record) {
+				values[column] = record[i]
+			}
+		}
+		rows = append(rows, newImportRow(len(rows)+1, values))
+	}
+	return rows, nil
+}
+
+// ReadImportJSON reads issues from a JSON array of objects. Values may be
+// strings, numbers or, for depends_on, arrays.
+func ReadImportJSON(r io.Reader) ([]ImportRow, error) {
+	var objects []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: expected an array of objects: %w", err)
+	}
+
+	rows := make([]ImportRow, 0, len(objects))
+	for i, object := range objects {
+		values := make(map[string]string)
+		for column, value := range object {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				values[column] = v
+			case []interface{}:
+				items := make([]string, 0, len(v))
+				for _, item := range v {
+					items = append(items, fmt.Sprint(item))
+				}
+				values[column] = strings.Join(items, ",")
+			default:
+				values[column] = fmt.Sprint(v)
+			}
+		}
+		rows = append(rows, newImportRow(i+1, values))
+	}
+	return rows, nil
+}
+
+// newImportRow builds a row from column values. Column names are matched
+// ignoring case, and "-" or spaces in them count as "_".
+func newImportRow(number int, values map[string]string) ImportRow {
+	row := ImportRow{Row: number, Fields: make(map[string]string)}
+	for column, value := range values {
+		value = strings.TrimSpace(value)
+		switch normalizeColumn(column) {
+		case columnKey:
+			row.Key = value
+		case columnType:
+			row.Type = value
+		case columnTitle:
+			row.Title = value
+		case columnTeam:
+			row.Team = value
+		case columnPriority:
+			row.Priority = value
+		case columnParent:
+			row.Parent = value
+		case columnDependsOn:
+			row.DependsOn = strings.FieldsFunc(value, func(r rune) bool {
+				return r == ',' || r == ';' || r == ' ' || r == '\n'
+			})
+		default:
+			if value != "" {
+				row.Fields[strings.TrimSpace(column)] = value
+			}
+		}
+	}
+	return row
+}
+
+func normalizeColumn(column string) string {
+	column = strings.ToLower(strings.TrimSpace(column))
+	return strings.NewReplacer("-", "_", " ", "_").Replace(column)
+}
+
+// ImportRowResult is the outcome of one row. Err is set if the row is
+// invalid or its issue couldn't be created.
+type ImportRowResult struct {
+	ImportRow
+	Issue *CreateIssueResult // nil if the issue wasn't created
+	Err   error
+}
+
+// ImportResult is the outcome of an import, one result per row in file order
+type ImportResult struct {
+	Rows []*ImportRowResult
+}
+
+// Created returns the number of rows whose issue was created
+func (r *ImportResult) Created() int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Issue != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Invalid returns the rows that failed validation or creation
+func (r *ImportResult) Invalid() []*ImportRowResult {
+	var invalid []*ImportRowResult
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			invalid = append(invalid, row)
+		}
+	}
+	return invalid
+}
+
+// ValidateImport checks every row without creating anything: its type, its
+// fields against its template with ValidateFields, that it has a title or
+// its template a title pattern to render one, and that its parent and
+// dependencies are issue references or keys of earlier rows. Rows that fail
+// have Err set.
+func (s *Service) ValidateImport(ctx context.Context, rows []ImportRow) *ImportResult {
+	result := &ImportResult{}
+	validator := s.newFieldValidator()
+	keys := make(map[string]int)
+
+	for _, row := range rows {
+		rowResult := &ImportRowResult{ImportRow: row}
+		rowResult.Err = s.validateImportRow(ctx, validator, row, keys)
+		result.Rows = append(result.Rows, rowResult)
+
+		if row.Key != "" {
+			if _, ok := keys[row.Key]; !ok {
+				keys[row.Key] = row.Row
+			}
+		}
+	}
+	return result
+}
+
+func (s *Service) validateImportRow(ctx context.Context, validator *fieldValidator, row ImportRow, keys map[string]int) error {
+	if row.Type == "" {
+		return fmt.Errorf("missing type")
+	}
+	if row.Key != "" {
+		if _, err := strconv.Atoi(strings.TrimPrefix(row.Key, "#")); err == nil {
+			return fmt.Errorf("key '%s' looks like an issue number", row.Key)
+		}
+		if previous, ok := keys[row.Key]; ok {
+			return fmt.Errorf("key '%s' is already used by row %d", row.Key, previous)
+		}
+	}
+
+	if err := validator.validate(ctx, row.Type, row.Fields); err != nil {
+		return err
+	}
+	if err := validator.validateTitle(ctx, row.Type, row.Title, row.Team, row.Fields); err != nil {
+		return err
+	}
+
+	if _, _, err := s.resolveImportRef(row.Parent, keys); err != nil {
+		return fmt.Errorf("invalid parent: %w", err)
+	}
+	for _, dependency := range row.DependsOn {
+		if _, _, err := s.resolveImportRef(dependency, keys); err != nil {
+			return fmt.Errorf("invalid depends_on: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolveImportRef resolves a parent or dependency to the row it names, or
+// to an issue reference. It returns row 0 and a zero reference for "".
+func (s *Service) resolveImportRef(value string, keys map[string]int) (int, IssueRef, error) {
+	if value == "" {
+		return 0, IssueRef{}, nil
+	}
+	if row, ok := keys[value]; ok {
+		return row, IssueRef{}, nil
+	}
+	ref, err := s.ParseRef(value)
+	if err != nil {
+		return 0, IssueRef{}, fmt.Errorf("'%s' is neither an issue nor the key of an earlier row", value)
+	}
+	return 0, ref, nil
+}
+
+// Import creates an issue per row, in file order, after validating all rows
+// with ValidateImport. If any row is invalid nothing is created and the
+// result reports every invalid row.
+//
+// Issues are created in the default repository, linked to their parent and
+// marked as blocked by their dependencies. If transfer is set, issues with a
+// Team are then transferred to the team's repository; as with
+// CreateHierarchy, transfers run only after every row has been created, and
+// not at all if a row failed.
+func (s *Service) Import(ctx context.Context, rows []ImportRow, transfer bool) (*ImportResult, error) {
+	result := s.ValidateImport(ctx, rows)
+	if invalid := result.Invalid(); len(invalid) > 0 {
+		return result, fmt.Errorf("%d of %d rows are invalid, no issue was created", len(invalid), len(rows))
+	}
+
+	keys := make(map[string]int)
+	created := make(map[int]IssueRef)
+	resolve := func(value string) IssueRef {
+		row, ref, _ := s.resolveImportRef(value, keys)
+		if row != 0 {
+			return created[row]
+		}
+		return ref
+	}
+
+	for _, row := range result.Rows {
+		params := CreateIssueParams{
+			Type:   row.Type,
+			Title:  row.Title,
+			Fields: row.Fields,
+			Parent: resolve(row.Parent),
+		}
+		params.ProjectFields = make(map[string]string)
+		if row.Team != "" {
+			params.ProjectFields["Team"] = row.Team
+		}
+		if row.Priority != "" {
+			params.ProjectFields["Priority"] = row.Priority
+		}
+		for _, dependency := range row.DependsOn {
+			params.BlockedBy = append(params.BlockedBy, resolve(dependency))
+		}
+
+		issue, err := s.createIssue(ctx, params, nil)
+		if issue != nil {
+			row.Issue = issue
+			row.Title = issue.Issue.Title
+		}
+		if err != nil {
+			row.Err = err
+			return result, fmt.Errorf("row %d: %w", row.Row, err)
+		}
+
+		created[row.Row] = issue.Ref
+		if row.Key != "" {
+			keys[row.Key] = row.Row
+		}
+	}
+
+	if transfer {
+		// File order moves parents before their children
+		for _, row := range result.Rows {
+			s.transferToTeam(ctx, row.Issue, row.Team)
+		}
+	}
+
+	return result, nil
+}
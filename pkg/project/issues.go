@@ -0,0 +1,414 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+	"github.com/Zytera/gh-project-management/pkg/issue"
+)
+
+// CreateIssueParams describes an issue to create in the context's default repository
+type CreateIssueParams struct {
+	Type          string            // Template type: epic, story, task, bug, feature or a custom template
+	Title         string            // Rendered from the template's title pattern if empty
+	Fields        map[string]string // Template field ID -> value
+	ProjectFields map[string]string // Project single-select field name -> option, e.g. "Team": "Backend"
+	Parent        IssueRef          // Optional: parent issue, must be in the default repository
+	BlockedBy     []IssueRef        // Optional: issues in the default repository that block this one
+	Transfer      bool              // Transfer to the team repository of the "Team" project field
+	Key           string            // Optional: stored in the body so Apply can find the issue again
+	Labels        []string          // Added to the template's labels; must exist in the default repository
+	Assignees     []string          // Logins; "@me" is the current user
+	Milestone     string            // Title or number of an open milestone of the default repository
+	Atomic        bool              // CreateIssue only: undo every completed step if a later one fails
+}
+
+// CreateIssueResult is the outcome of creating an issue. Steps after the issue
+// itself is created don't fail the operation; their errors are in Warnings.
+type CreateIssueResult struct {
+	Issue          *Issue
+	Ref            IssueRef // Where the issue lives now, after any transfer
+	ProjectItemID  string
+	TemplateSource string
+	Labels         []string          // Names of the labels set on the issue
+	Parent         IssueRef          // Set if the issue was linked to its parent
+	FieldsSet      map[string]string // Project fields that were set
+	BlockedBy      []IssueRef        // Dependencies that were added
+	Transferred    bool
+	Warnings       []error
+	RolledBack     []RolledBackStep // Steps undone after a failure, in the order they were undone
+}
+
+func (r *CreateIssueResult) warn(err error) {
+	r.Warnings = append(r.Warnings, err)
+}
+
+// CreateIssue creates an issue from its template, adds it to the project, links
+// it to its parent, sets project fields, adds dependencies and, if requested,
+// transfers it to the team repository.
+//
+// Failures after the issue is created are only warnings, unless params.Atomic
+// is set: then the first failure stops the creation and the completed steps
+// are undone in reverse order. The sub-issue link and dependencies are removed,
+// the issue is removed from the project and then deleted, or closed if it
+// can't be deleted. The result lists what was rolled back and is returned with
+// the error. Re-applying labels after a transfer only warns either way.
+//
+// Without params.Atomic, an issue that was created but couldn't be added to
+// the project is returned with the error, so callers can report where it is.
+func (s *Service) CreateIssue(ctx context.Context, params CreateIssueParams) (*CreateIssueResult, error) {
+	var undo *undoLog
+	if params.Atomic {
+		undo = &undoLog{}
+	}
+
+	result, err := s.createIssue(ctx, params, undo)
+	if err == nil && params.Transfer {
+		err = s.transferToTeam(ctx, result, params.ProjectFields["Team"])
+		if undo == nil {
+			// Already recorded in the warnings
+			err = nil
+		}
+	}
+	if err != nil && undo != nil && result != nil {
+		result.RolledBack = s.rollback(ctx, result.Ref, undo)
+	}
+	return result, err
+}
+
+// createIssue runs every step of CreateIssue except the transfer. If undo is
+// set, it records each completed step there and stops at the first failure,
+// returning the partial result with the error. An issue that was created but
+// couldn't be added to the project is returned with the error either way.
+func (s *Service) createIssue(ctx context.Context, params CreateIssueParams, undo *undoLog) (*CreateIssueResult, error) {
+	created, err := issue.CreateDynamicIssue(ctx, s.Client, issue.CreateDynamicIssueParams{
+		Config:    s.Config,
+		IssueType: params.Type,
+		Title:     params.Title,
+		Team:      params.ProjectFields["Team"],
+		Fields:    params.Fields,
+		Key:       params.Key,
+		Labels:    params.Labels,
+		Assignees: params.Assignees,
+		Milestone: params.Milestone,
+	})
+	if err != nil {
+		s.emit(StepCreate, IssueRef{}, err, "failed to create %s '%s'", params.Type, params.Title)
+		if created != nil {
+			// Created but not added to the project
+			result := &CreateIssueResult{
+				Issue:          created.Issue,
+				Ref:            s.Ref(created.Issue.Number),
+				TemplateSource: created.TemplateSource,
+				Labels:         created.Labels,
+				Warnings:       created.Warnings,
+			}
+			undo.issueCreated(s, result)
+			return result, err
+		}
+		return nil, err
+	}
+
+	result := &CreateIssueResult{
+		Issue:          created.Issue,
+		Ref:            s.Ref(created.Issue.Number),
+		ProjectItemID:  created.ProjectItemID,
+		TemplateSource: created.TemplateSource,
+		Labels:         created.Labels,
+		FieldsSet:      make(map[string]string),
+		Warnings:       created.Warnings,
+	}
+	s.emit(StepCreate, result.Ref, nil, "created %s %s: %s", params.Type, result.Ref, created.Issue.Title)
+	for _, warning := range created.Warnings {
+		s.emit(StepCreate, result.Ref, warning, "%v", warning)
+	}
+	undo.issueCreated(s, result)
+	undo.addedToProject(s, result)
+
+	if !params.Parent.IsZero() {
+		if err := s.Link(ctx, params.Parent, result.Ref); err != nil {
+			result.warn(err)
+			if undo != nil {
+				return result, err
+			}
+		} else {
+			result.Parent = params.Parent
+			undo.linked(s, params.Parent, result.Ref)
+		}
+	}
+
+	if len(params.ProjectFields) > 0 {
+		// Fields go away with the project item, so they need no undo step
+		set, err := s.setFields(ctx, result.Ref, result.ProjectItemID, params.ProjectFields)
+		result.FieldsSet = set
+		if err != nil {
+			result.warn(err)
+			if undo != nil {
+				return result, err
+			}
+		}
+	}
+
+	for _, blocking := range params.BlockedBy {
+		if err := s.AddBlockedBy(ctx, result.Ref, blocking); err != nil {
+			result.warn(err)
+			if undo != nil {
+				return result, err
+			}
+		} else {
+			result.BlockedBy = append(result.BlockedBy, blocking)
+			undo.blockedBy(s, result.Ref, blocking)
+		}
+	}
+
+	return result, nil
+}
+
+// fieldValidator checks template fields before issues are created, loading each template once
+type fieldValidator struct {
+	service   *Service
+	templates map[string]*templates.IssueTemplate
+}
+
+func (s *Service) newFieldValidator() *fieldValidator {
+	return &fieldValidator{service: s, templates: make(map[string]*templates.IssueTemplate)}
+}
+
+// template returns the template of issueType
+func (v *fieldValidator) template(ctx context.Context, issueType string) (*templates.IssueTemplate, error) {
+	if template, ok := v.templates[issueType]; ok {
+		return template, nil
+	}
+
+	cfg := v.service.Config
+	template, _, err := issue.GetTemplate(ctx, v.service.Client, cfg.Owner, cfg.DefaultRepo, issueType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template for type %s: %w", issueType, err)
+	}
+	v.templates[issueType] = template
+	return template, nil
+}
+
+// validate checks fields against the template of issueType, rejecting IDs the template doesn't have
+func (v *fieldValidator) validate(ctx context.Context, issueType string, fields map[string]string) error {
+	template, err := v.template(ctx, issueType)
+	if err != nil {
+		return err
+	}
+
+	if err := templates.ValidateFields(template, fields); err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	for _, field := range template.GetAllInputFields() {
+		known[field.ID] = true
+	}
+	names := make([]string, 0, len(fields))
+	for id := range fields {
+		names = append(names, id)
+	}
+	sort.Strings(names)
+	for _, id := range names {
+		if !known[id] {
+			return fmt.Errorf("unknown field '%s' for type %s", id, issueType)
+		}
+	}
+	return nil
+}
+
+// validateTitle checks that an issue without title gets one from the title pattern of its template
+func (v *fieldValidator) validateTitle(ctx context.Context, issueType, title, team string, fields map[string]string) error {
+	if title != "" {
+		return nil
+	}
+
+	template, err := v.template(ctx, issueType)
+	if err != nil {
+		return err
+	}
+	_, err = issue.RenderTitle(template, issueType, team, fields)
+	return err
+}
+
+// transferToTeam moves a created issue to the repository of team, recording
+// the outcome in result. It returns the error of a failed transfer, which is
+// also recorded as a warning.
+func (s *Service) transferToTeam(ctx context.Context, result *CreateIssueResult, team string) error {
+	if team == "" {
+		return nil
+	}
+
+	source := result.Ref
+	ref, err := s.TransferToTeam(ctx, source, team)
+	if err != nil {
+		result.warn(err)
+		return err
+	}
+	result.Ref = ref
+	result.Transferred = true
+
+	if err := s.reapplyLabels(ctx, source, ref, result.Labels); err != nil {
+		result.warn(err)
+	}
+	return nil
+}
+
+// reapplyLabels restores labels of an issue transferred from source to ref
+// that the target repository didn't have, creating them there
+func (s *Service) reapplyLabels(ctx context.Context, source, ref IssueRef, labels []string) error {
+	if ref == source || len(labels) == 0 {
+		return nil
+	}
+
+	if err := gh.ReapplyLabels(ctx, s.Client, ref.Owner, source.Repo, ref.Repo, ref.Number, labels); err != nil {
+		err = fmt.Errorf("failed to re-apply labels after transfer: %w", err)
+		s.emit(StepTransfer, ref, err, "failed to re-apply labels")
+		return err
+	}
+	s.emit(StepTransfer, ref, nil, "re-applied labels %s", strings.Join(labels, ", "))
+	return nil
+}
+
+// SetFields sets project single-select fields (field name -> option) on an issue,
+// adding the issue to the project if needed. It stops at the first failure and
+// returns the fields set so far.
+func (s *Service) SetFields(ctx context.Context, ref IssueRef, fields map[string]string) (map[string]string, error) {
+	projectNodeID, err := s.ProjectNodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issueNodeID, err := s.Client.GetIssueNodeID(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue node ID: %w", err)
+	}
+
+	projectItemID, err := gh.EnsureProjectItem(ctx, s.Client, projectNodeID, issueNodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project item ID: %w", err)
+	}
+
+	return s.setFields(ctx, ref, projectItemID, fields)
+}
+
+func (s *Service) setFields(ctx context.Context, ref IssueRef, projectItemID string, fields map[string]string) (map[string]string, error) {
+	set := make(map[string]string)
+
+	projectNodeID, err := s.ProjectNodeID(ctx)
+	if err != nil {
+		s.emit(StepField, ref, err, "failed to resolve project")
+		return set, err
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fields[name]
+
+		field, optionID, err := gh.FindFieldOption(ctx, s.Client, projectNodeID, name, value)
+		if err == nil {
+			err = s.Client.UpdateProjectItemField(ctx, projectNodeID, projectItemID, field.ID, optionID)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to set %s field: %w", name, err)
+			s.emit(StepField, ref, err, "failed to set %s to %s", name, value)
+			return set, err
+		}
+
+		set[name] = value
+		s.emit(StepField, ref, nil, "set %s to %s", name, value)
+	}
+	return set, nil
+}
+
+// Link makes child a sub-issue of parent. Both must be in the same repository.
+func (s *Service) Link(ctx context.Context, parent, child IssueRef) error {
+	if parent.Owner != child.Owner || parent.Repo != child.Repo {
+		err := fmt.Errorf("cannot link %s to %s: sub-issues must be in the parent's repository", child, parent)
+		s.emit(StepLink, child, err, "failed to link to %s", parent)
+		return err
+	}
+
+	if err := s.Client.AddSubIssue(ctx, parent.Owner, parent.Repo, parent.Number, child.Number); err != nil {
+		err = fmt.Errorf("failed to link %s to %s: %w", child, parent, err)
+		s.emit(StepLink, child, err, "failed to link to %s", parent)
+		return err
+	}
+
+	s.emit(StepLink, child, nil, "linked to parent %s", parent)
+	return nil
+}
+
+// Unlink removes child from the sub-issues of parent
+func (s *Service) Unlink(ctx context.Context, parent, child IssueRef) error {
+	if parent.Owner != child.Owner || parent.Repo != child.Repo {
+		err := fmt.Errorf("cannot unlink %s from %s: sub-issues must be in the parent's repository", child, parent)
+		s.emit(StepLink, child, err, "failed to unlink from %s", parent)
+		return err
+	}
+
+	if err := s.Client.RemoveSubIssue(ctx, parent.Owner, parent.Repo, parent.Number, child.Number); err != nil {
+		err = fmt.Errorf("failed to unlink %s from %s: %w", child, parent, err)
+		s.emit(StepLink, child, err, "failed to unlink from %s", parent)
+		return err
+	}
+
+	s.emit(StepLink, child, nil, "unlinked from parent %s", parent)
+	return nil
+}
+
+// AddBlockedBy marks blocked as blocked by blocking. Both must be in the same repository.
+func (s *Service) AddBlockedBy(ctx context.Context, blocked, blocking IssueRef) error {
+	if blocked.Owner != blocking.Owner || blocked.Repo != blocking.Repo {
+		err := fmt.Errorf("cannot add dependency of %s on %s: issues must be in the same repository", blocked, blocking)
+		s.emit(StepDependency, blocked, err, "failed to add dependency on %s", blocking)
+		return err
+	}
+
+	if err := s.Client.AddBlockedBy(ctx, blocked.Owner, blocked.Repo, blocked.Number, blocking.Number); err != nil {
+		err = fmt.Errorf("failed to add dependency of %s on %s: %w", blocked, blocking, err)
+		s.emit(StepDependency, blocked, err, "failed to add dependency on %s", blocking)
+		return err
+	}
+
+	s.emit(StepDependency, blocked, nil, "blocked by %s", blocking)
+	return nil
+}
+
+// Transfer moves an issue to another repository of the same owner and returns its new reference
+func (s *Service) Transfer(ctx context.Context, ref IssueRef, targetRepo string) (IssueRef, error) {
+	sourceRepo := fmt.Sprintf("%s/%s", ref.Owner, ref.Repo)
+	number, err := s.Client.TransferIssue(ctx, ref.Number, ref.Owner, targetRepo, sourceRepo)
+	if err != nil {
+		err = fmt.Errorf("failed to transfer %s to %s/%s: %w", ref, ref.Owner, targetRepo, err)
+		s.emit(StepTransfer, ref, err, "failed to transfer to %s/%s", ref.Owner, targetRepo)
+		return IssueRef{}, err
+	}
+
+	newRef := IssueRef{Owner: ref.Owner, Repo: targetRepo, Number: number}
+	s.emit(StepTransfer, newRef, nil, "transferred %s to %s", ref, newRef)
+	return newRef, nil
+}
+
+// TransferToTeam moves an issue to the repository configured for team
+func (s *Service) TransferToTeam(ctx context.Context, ref IssueRef, team string) (IssueRef, error) {
+	targetRepo, ok := s.Config.TeamRepos[team]
+	if !ok {
+		err := fmt.Errorf("no repository configured for team '%s'", team)
+		s.emit(StepTransfer, ref, err, "skipped transfer")
+		return IssueRef{}, err
+	}
+	if targetRepo == ref.Repo {
+		return ref, nil
+	}
+	return s.Transfer(ctx, ref, targetRepo)
+}
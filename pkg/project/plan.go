@@ -0,0 +1,501 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+	"gopkg.in/yaml.v3"
+)
+
+// Plan is an epic tree described in a plan file
+type Plan struct {
+	Epic PlanNode `yaml:"epic"`
+}
+
+// PlanNode is an issue in a plan. Its key identifies it within the plan:
+// other nodes refer to it in depends_on, and it is stored in the issue body
+// so applying the plan again finds the issue instead of creating it twice.
+type PlanNode struct {
+	Key        string            `yaml:"key"`
+	Type       string            `yaml:"type"`  // Default: epic at the root, user_story below it, task further down
+	Title      string            `yaml:"title"` // Default: rendered from the template's title pattern
+	Team       string            `yaml:"team"`
+	Priority   string            `yaml:"priority"`
+	NoTransfer bool              `yaml:"no_transfer"` // Keep the issue in the default repository even if team is set
+	Fields     map[string]string `yaml:"fields"`      // Template field ID -> value
+	DependsOn  []string          `yaml:"depends_on"`  // Keys of the nodes that block this one
+	Children   []PlanNode        `yaml:"children"`
+}
+
+// defaultPlanTypes are the template types of nodes without a type, by depth
+var defaultPlanTypes = []string{"epic", "user_story", "task"}
+
+// LoadPlan reads and checks a plan file
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	return ParsePlan(data)
+}
+
+// ParsePlan parses a YAML plan and checks that every node has a unique key,
+// that dependencies refer to keys of the plan and that they don't form a
+// cycle. Nodes without a type get their default type.
+func ParsePlan(data []byte) (*Plan, error) {
+	var plan Plan
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	if plan.Epic.Key == "" && plan.Epic.Title == "" {
+		return nil, fmt.Errorf("plan has no epic")
+	}
+	if _, err := plan.order(); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// planEntry is a node of a plan with its place in the tree
+type planEntry struct {
+	node   *PlanNode
+	parent *planEntry
+}
+
+// storedKey is the key written to the issue body. Keys of descendants are
+// prefixed with the epic's key so several plans can use the same local keys.
+func (p *Plan) storedKey(node *PlanNode) string {
+	if node == &p.Epic {
+		return node.Key
+	}
+	return p.Epic.Key + "/" + node.Key
+}
+
+// order returns the nodes of the plan so that every node comes after its
+// parent and the nodes it depends on, keeping plan order otherwise
+func (p *Plan) order() ([]*planEntry, error) {
+	var entries []*planEntry
+	byKey := make(map[string]*planEntry)
+
+	var walk func(node *PlanNode, parent *planEntry, depth int) error
+	walk = func(node *PlanNode, parent *planEntry, depth int) error {
+		if node.Key == "" {
+			return fmt.Errorf("plan node '%s' has no key", node.Title)
+		}
+		if strings.ContainsAny(node.Key, " \t\n") {
+			return fmt.Errorf("plan key '%s' must not contain spaces", node.Key)
+		}
+		if _, ok := byKey[node.Key]; ok {
+			return fmt.Errorf("plan key '%s' is used more than once", node.Key)
+		}
+		if node.Type == "" {
+			node.Type = defaultPlanTypes[min(depth, len(defaultPlanTypes)-1)]
+		}
+
+		entry := &planEntry{node: node, parent: parent}
+		entries = append(entries, entry)
+		byKey[node.Key] = entry
+
+		for i := range node.Children {
+			if err := walk(&node.Children[i], entry, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(&p.Epic, nil, 0); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		for _, key := range entry.node.DependsOn {
+			if _, ok := byKey[key]; !ok {
+				return nil, fmt.Errorf("plan node '%s' depends on unknown key '%s'", entry.node.Key, key)
+			}
+			if key == entry.node.Key {
+				return nil, fmt.Errorf("plan node '%s' depends on itself", key)
+			}
+		}
+	}
+
+	// Repeatedly take the first node whose parent and dependencies are placed
+	placed := make(map[*planEntry]bool)
+	ordered := make([]*planEntry, 0, len(entries))
+	for len(ordered) < len(entries) {
+		progress := false
+		for _, entry := range entries {
+			if placed[entry] || (entry.parent != nil && !placed[entry.parent]) {
+				continue
+			}
+			ready := true
+			for _, key := range entry.node.DependsOn {
+				if !placed[byKey[key]] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				placed[entry] = true
+				ordered = append(ordered, entry)
+				progress = true
+			}
+		}
+		if !progress {
+			var stuck []string
+			for _, entry := range entries {
+				if !placed[entry] {
+					stuck = append(stuck, entry.node.Key)
+				}
+			}
+			return nil, fmt.Errorf("plan has a dependency cycle between: %s", strings.Join(stuck, ", "))
+		}
+	}
+
+	return ordered, nil
+}
+
+// AppliedIssue is the outcome of applying one node of a plan
+type AppliedIssue struct {
+	Key         string
+	Type        string
+	Title       string
+	Ref         IssueRef // Where the issue lives after the apply
+	Created     bool     // False if the issue already existed
+	Transferred bool
+	Warnings    []error
+
+	labels []string // Labels set when the issue was created
+}
+
+func (a *AppliedIssue) warn(err error) {
+	a.Warnings = append(a.Warnings, err)
+}
+
+// ApplyResult is the outcome of applying a plan, in creation order
+type ApplyResult struct {
+	Issues []*AppliedIssue
+}
+
+// Created returns the number of issues the apply created
+func (r *ApplyResult) Created() int {
+	count := 0
+	for _, applied := range r.Issues {
+		if applied.Created {
+			count++
+		}
+	}
+	return count
+}
+
+// AllWarnings returns the warnings of every issue
+func (r *ApplyResult) AllWarnings() []error {
+	var warnings []error
+	for _, applied := range r.Issues {
+		warnings = append(warnings, applied.Warnings...)
+	}
+	return warnings
+}
+
+// planRelation is a sub-issue link or dependency between two plan issues
+type planRelation struct {
+	from, to *AppliedIssue // Child and parent, or blocked and blocking
+	parent   bool
+}
+
+// Apply creates the issues of a plan that don't exist yet. Issues are matched
+// by the key stored in their body, so applying a plan again only creates what
+// is missing, sets the plan's Team and Priority where they differ and adds
+// missing links and dependencies. Bodies of existing issues are not changed.
+// Issues of an earlier apply that never reached the project are found in the
+// default repository and added to it.
+//
+// Issues are created in dependency order in the default repository, linked
+// and made blocked by their dependencies, and only then transferred to their
+// team's repository. Links and dependencies that couldn't be added because
+// the issues were in different repositories are retried after the transfers.
+//
+// The template fields of every new issue are validated before any issue is
+// created. Failures after that stop the apply and return the partial result;
+// applying the plan again continues where it stopped.
+func (s *Service) Apply(ctx context.Context, plan *Plan) (*ApplyResult, error) {
+	entries, err := plan.order()
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.findKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validatePlan(ctx, plan, entries, existing); err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*PlanNode)
+	for _, entry := range entries {
+		nodes[entry.node.Key] = entry.node
+	}
+
+	result := &ApplyResult{}
+	applied := make(map[*PlanNode]*AppliedIssue)
+	var relations []planRelation
+
+	// Create missing issues, with their project fields
+	for _, entry := range entries {
+		node := entry.node
+		key := plan.storedKey(node)
+		current := &AppliedIssue{Key: node.Key, Type: node.Type, Title: node.Title}
+		applied[node] = current
+
+		if item, ok := existing[key]; ok {
+			current.Ref = IssueRef{Owner: item.Owner, Repo: item.Repo, Number: item.Issue.Number}
+			s.emit(StepCreate, current.Ref, nil, "found %s %s: %s", node.Type, current.Ref, item.Issue.Title)
+			current.Title = item.Issue.Title
+
+			if item.ID == "" {
+				// Created by an apply that stopped before adding it to the project
+				if item.ID, err = s.addOrphan(ctx, current.Ref, item.Issue); err != nil {
+					result.Issues = append(result.Issues, current)
+					return result, err
+				}
+			}
+
+			changed := make(map[string]string)
+			for name, value := range node.projectFields() {
+				if item.Fields[name] != value {
+					changed[name] = value
+				}
+			}
+			if len(changed) > 0 {
+				if _, err := s.setFields(ctx, current.Ref, item.ID, changed); err != nil {
+					current.warn(err)
+				}
+			}
+		} else {
+			created, err := s.createIssue(ctx, CreateIssueParams{
+				Type:          node.Type,
+				Title:         node.Title,
+				Fields:        node.Fields,
+				ProjectFields: node.projectFields(),
+				Key:           key,
+			}, nil)
+			if err != nil {
+				if created != nil {
+					// Created but not added to the project
+					current.Ref = created.Ref
+					current.Created = true
+					current.Title = created.Issue.Title
+					result.Issues = append(result.Issues, current)
+				}
+				return result, err
+			}
+			current.Ref = created.Ref
+			current.Created = true
+			current.Title = created.Issue.Title
+			current.Warnings = created.Warnings
+			current.labels = created.Labels
+		}
+		result.Issues = append(result.Issues, current)
+
+		if entry.parent != nil {
+			relations = append(relations, planRelation{from: current, to: applied[entry.parent.node], parent: true})
+		}
+		for _, dependency := range node.DependsOn {
+			relations = append(relations, planRelation{from: current, to: applied[nodes[dependency]]})
+		}
+	}
+
+	relations, err = s.missingRelations(ctx, relations)
+	if err != nil {
+		return result, err
+	}
+	deferred := s.addRelations(ctx, relations, false)
+
+	// Transfer to team repositories, parents first
+	for _, entry := range entries {
+		node := entry.node
+		current := applied[node]
+		if node.Team == "" || node.NoTransfer {
+			continue
+		}
+		if repo, ok := s.Config.TeamRepos[node.Team]; ok && repo == current.Ref.Repo {
+			continue
+		}
+
+		source := current.Ref
+		ref, err := s.TransferToTeam(ctx, source, node.Team)
+		if err != nil {
+			current.warn(err)
+			continue
+		}
+		current.Ref = ref
+		current.Transferred = true
+
+		if err := s.reapplyLabels(ctx, source, ref, current.labels); err != nil {
+			current.warn(err)
+		}
+	}
+
+	s.addRelations(ctx, deferred, true)
+	return result, nil
+}
+
+// projectFields are the project fields a node sets
+func (n *PlanNode) projectFields() map[string]string {
+	fields := make(map[string]string)
+	if n.Team != "" {
+		fields["Team"] = n.Team
+	}
+	if n.Priority != "" {
+		fields["Priority"] = n.Priority
+	}
+	return fields
+}
+
+// orphanSearchLimit is how many of the newest issues of the default repository
+// findKeys searches for issues that never reached the project
+const orphanSearchLimit = 100
+
+// findKeys maps the keys stored in the bodies of the project's issues to their
+// items. Keys are also searched in the newest open issues of the default
+// repository: an apply that stopped after creating an issue but before adding
+// it to the project leaves such an issue behind. Its item has no ID.
+func (s *Service) findKeys(ctx context.Context) (map[string]gh.ProjectItem, error) {
+	projectNodeID, err := s.ProjectNodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.Client.ListProjectItems(ctx, projectNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]gh.ProjectItem)
+	for _, item := range items {
+		if key := templates.FindKey(item.Issue.Body); key != "" {
+			keys[key] = item
+		}
+	}
+
+	issues, err := s.Client.ListRecentIssues(ctx, s.Config.Owner, s.Config.DefaultRepo, orphanSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		key := templates.FindKey(issue.Body)
+		if _, ok := keys[key]; key == "" || ok {
+			continue
+		}
+		keys[key] = gh.ProjectItem{Issue: issue, Owner: s.Config.Owner, Repo: s.Config.DefaultRepo, State: "OPEN"}
+	}
+	return keys, nil
+}
+
+// addOrphan adds an issue findKeys found outside the project to it and returns its item ID
+func (s *Service) addOrphan(ctx context.Context, ref IssueRef, issue gh.Issue) (string, error) {
+	projectNodeID, err := s.ProjectNodeID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	itemID, err := s.Client.AddIssueToProject(ctx, projectNodeID, issue.ID)
+	if err != nil {
+		err = fmt.Errorf("failed to add %s to the project: %w", ref, err)
+		s.emit(StepCreate, ref, err, "%v", err)
+		return "", err
+	}
+	s.emit(StepCreate, ref, nil, "added %s to the project", ref)
+	return itemID, nil
+}
+
+// validatePlan checks the template fields and title of every issue the apply will create
+func (s *Service) validatePlan(ctx context.Context, plan *Plan, entries []*planEntry, existing map[string]gh.ProjectItem) error {
+	validator := s.newFieldValidator()
+	for _, entry := range entries {
+		node := entry.node
+		if _, ok := existing[plan.storedKey(node)]; ok {
+			continue
+		}
+		if err := validator.validate(ctx, node.Type, node.Fields); err != nil {
+			return fmt.Errorf("plan node '%s': %w", node.Key, err)
+		}
+		if err := validator.validateTitle(ctx, node.Type, node.Title, node.Team, node.Fields); err != nil {
+			return fmt.Errorf("plan node '%s': %w", node.Key, err)
+		}
+	}
+	return nil
+}
+
+// missingRelations drops the links and dependencies that already exist
+func (s *Service) missingRelations(ctx context.Context, relations []planRelation) ([]planRelation, error) {
+	var missing []planRelation
+	for _, relation := range relations {
+		// Relations of new issues can't exist yet
+		if relation.from.Created || relation.to.Created {
+			missing = append(missing, relation)
+			continue
+		}
+
+		ref := relation.from.Ref
+		details, err := s.Client.GetIssueDetails(ctx, ref.Owner, ref.Repo, ref.Number)
+		if err != nil {
+			return nil, err
+		}
+
+		if relation.parent {
+			if details.Parent == nil {
+				missing = append(missing, relation)
+			} else if details.Parent.Ref() != relation.to.Ref.String() {
+				relation.from.warn(fmt.Errorf("%s has parent %s, not %s as in the plan", ref, details.Parent.Ref(), relation.to.Ref))
+			}
+			continue
+		}
+
+		found := false
+		for _, blocking := range details.BlockedBy {
+			if blocking.Ref() == relation.to.Ref.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, relation)
+		}
+	}
+	return missing, nil
+}
+
+// addRelations adds sub-issue links and dependencies. Relations between
+// issues in different repositories are returned to be retried after the
+// transfers, or recorded as warnings on the last attempt.
+func (s *Service) addRelations(ctx context.Context, relations []planRelation, last bool) []planRelation {
+	var deferred []planRelation
+	for _, relation := range relations {
+		from, to := relation.from.Ref, relation.to.Ref
+		if !last && (from.Owner != to.Owner || from.Repo != to.Repo) {
+			deferred = append(deferred, relation)
+			continue
+		}
+
+		var err error
+		if relation.parent {
+			err = s.Link(ctx, to, from)
+		} else {
+			err = s.AddBlockedBy(ctx, from, to)
+		}
+		if err != nil {
+			relation.from.warn(err)
+		}
+	}
+	return deferred
+}
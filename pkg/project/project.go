@@ -0,0 +1,201 @@
+// Package project is the Go API of gh-project-management, for programs that
+// create and organize issues without going through the CLI. A Service takes
+// its client and configuration explicitly, returns structured results and
+// never prints; callers that want to follow along pass a ProgressFunc.
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/Zytera/gh-project-management/internal/config"
+	"github.com/Zytera/gh-project-management/internal/gh"
+	"github.com/Zytera/gh-project-management/internal/templates"
+)
+
+// Types shared with the rest of the module, re-exported so importers
+// outside this module can name them
+type (
+	Client    = gh.Client
+	Config    = config.Config
+	OwnerType = config.OwnerType
+	Issue     = gh.Issue
+	Field     = gh.Field
+	APIError  = gh.Error
+)
+
+// Types in the methods of Client, so a program can implement or wrap Client,
+// e.g. to fake GitHub in its tests, without importing internal packages
+type (
+	Organization        = gh.Organization
+	Repository          = gh.Repository
+	Project             = gh.Project
+	FieldOption         = gh.FieldOption
+	FieldColor          = gh.FieldColor
+	CreateIssueOptions  = gh.CreateIssueOptions
+	IssueDetails        = gh.IssueDetails
+	IssueSummary        = gh.IssueSummary
+	ProjectFieldValues  = gh.ProjectFieldValues
+	Label               = gh.Label
+	Milestone           = gh.Milestone
+	DraftIssue          = gh.DraftIssue
+	ProjectItem         = gh.ProjectItem
+	RateLimit           = gh.RateLimit
+	IssueTypeConfig     = templates.IssueTypeConfig
+	IssueTemplate       = templates.IssueTemplate
+	BodyField           = templates.BodyField
+	FieldAttributes     = templates.FieldAttributes
+	CheckboxOption      = templates.CheckboxOption
+	Validations         = templates.Validations
+	FieldExtension      = templates.FieldExtension
+	ExtendedValidations = templates.ExtendedValidations
+)
+
+// Owner types
+const (
+	OwnerTypeUser = config.OwnerTypeUser
+	OwnerTypeOrg  = config.OwnerTypeOrg
+)
+
+// Error kinds. Use errors.Is to check which kind an error returned by a Service is.
+var (
+	ErrMissingScope          = gh.ErrMissingScope
+	ErrPermissionDenied      = gh.ErrPermissionDenied
+	ErrIssueNotFound         = gh.ErrIssueNotFound
+	ErrRepoNotFound          = gh.ErrRepoNotFound
+	ErrProjectNotFound       = gh.ErrProjectNotFound
+	ErrIssueTypesUnavailable = gh.ErrIssueTypesUnavailable
+)
+
+// NewClient creates a client for host using the gh CLI's token for that host.
+// An empty host uses the gh CLI's default host. Rate limited and transient
+// failures are retried silently.
+func NewClient(host string) (Client, error) {
+	client, err := gh.NewDefaultClient(host)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// LoadConfig loads the current context from the gh-project-management config file
+func LoadConfig() (*Config, error) {
+	return config.Load()
+}
+
+// Hint returns an actionable suggestion for resolving an API error, or "" if err has none
+func Hint(err error) string {
+	return gh.Hint(err)
+}
+
+// Step identifies the operation a progress event belongs to
+type Step string
+
+const (
+	StepCreate     Step = "create"
+	StepLink       Step = "link"
+	StepField      Step = "field"
+	StepDependency Step = "dependency"
+	StepTransfer   Step = "transfer"
+	StepRollback   Step = "rollback"
+)
+
+// Event reports progress of a Service operation.
+// Err is set when the step failed; the operation may still continue.
+type Event struct {
+	Step    Step
+	Issue   IssueRef // The issue the step applies to, if known
+	Message string
+	Err     error
+}
+
+// ProgressFunc receives progress events. It is called synchronously.
+type ProgressFunc func(Event)
+
+// Service performs project operations against one context
+type Service struct {
+	Client   Client
+	Config   *Config
+	Progress ProgressFunc // Optional
+
+	mu            sync.Mutex
+	projectNodeID string
+}
+
+// New creates a Service. progress may be nil.
+func New(client Client, cfg *Config, progress ProgressFunc) *Service {
+	return &Service{Client: client, Config: cfg, Progress: progress}
+}
+
+// emit sends an event to the progress callback, if any
+func (s *Service) emit(step Step, ref IssueRef, err error, format string, args ...interface{}) {
+	if s.Progress == nil {
+		return
+	}
+	s.Progress(Event{Step: step, Issue: ref, Message: fmt.Sprintf(format, args...), Err: err})
+}
+
+// ProjectNodeID resolves the node ID of the configured project
+func (s *Service) ProjectNodeID(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.projectNodeID != "" {
+		return s.projectNodeID, nil
+	}
+
+	projectNumber, err := strconv.Atoi(s.Config.ProjectID)
+	if err != nil {
+		return "", fmt.Errorf("invalid project ID '%s': %w", s.Config.ProjectID, err)
+	}
+
+	var projectNodeID string
+	if s.Config.OwnerType == config.OwnerTypeOrg {
+		projectNodeID, err = s.Client.GetProjectNodeID(ctx, s.Config.Owner, projectNumber)
+	} else {
+		projectNodeID, err = s.Client.GetUserProjectNodeID(ctx, projectNumber)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get project node ID: %w", err)
+	}
+
+	s.projectNodeID = projectNodeID
+	return projectNodeID, nil
+}
+
+// IssueRef identifies an issue by repository and number
+type IssueRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// String formats the reference as owner/repo#number, or "" if it is unset
+func (r IssueRef) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// IsZero reports whether the reference is unset
+func (r IssueRef) IsZero() bool {
+	return r == IssueRef{}
+}
+
+// ParseRef parses "owner/repo#number", "#number", "number" or an issue URL.
+// Short forms refer to the context's default repository.
+func (s *Service) ParseRef(ref string) (IssueRef, error) {
+	owner, repo, number, err := gh.ParseIssueReference(ref, s.Config.Host, s.Config.Owner, s.Config.DefaultRepo)
+	if err != nil {
+		return IssueRef{}, err
+	}
+	return IssueRef{Owner: owner, Repo: repo, Number: number}, nil
+}
+
+// Ref returns a reference to an issue in the context's default repository
+func (s *Service) Ref(number int) IssueRef {
+	return IssueRef{Owner: s.Config.Owner, Repo: s.Config.DefaultRepo, Number: number}
+}
@@ -0,0 +1,168 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Zytera/gh-project-management/internal/gh/fake"
+	"github.com/Zytera/gh-project-management/pkg/project"
+)
+
+// recordingClient wraps a Client using only names exported by pkg/project
+type recordingClient struct {
+	project.Client
+	created []project.CreateIssueOptions
+}
+
+func (c *recordingClient) CreateIssue(ctx context.Context, owner, repo, title, body string, opts project.CreateIssueOptions) (*project.Issue, error) {
+	c.created = append(c.created, opts)
+	return c.Client.CreateIssue(ctx, owner, repo, title, body, opts)
+}
+
+func TestServiceUsesWrappedClient(t *testing.T) {
+	backend := fake.New("me")
+	backend.AddOrganization("acme", "Acme")
+	backend.AddRepo("acme", "pm")
+	backend.AddProject("acme", 1, "Roadmap")
+
+	client := &recordingClient{Client: backend}
+	cfg := &project.Config{
+		OwnerType:   project.OwnerTypeOrg,
+		Owner:       "acme",
+		ProjectID:   "1",
+		DefaultRepo: "pm",
+	}
+	service := project.New(client, cfg, nil)
+
+	result, err := service.CreateIssue(context.Background(), project.CreateIssueParams{
+		Type:  "task",
+		Title: "Add login endpoint",
+		Fields: map[string]string{
+			"description":         "POST /login",
+			"checklist":           "- [ ] Handler",
+			"acceptance_criteria": "Returns a token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if len(client.created) != 1 {
+		t.Fatalf("wrapped CreateIssue called %d times, want 1", len(client.created))
+	}
+	if client.created[0].IssueTypeID == "" {
+		t.Errorf("issue created without an issue type")
+	}
+	if want := (project.IssueRef{Owner: "acme", Repo: "pm", Number: 1}); result.Ref != want {
+		t.Errorf("Ref = %v, want %v", result.Ref, want)
+	}
+}
+
+// unassignableClient fails to add issues to the project
+type unassignableClient struct {
+	project.Client
+}
+
+func (c *unassignableClient) AddIssueToProject(ctx context.Context, projectID, issueNodeID string) (string, error) {
+	return "", errors.New("project is full")
+}
+
+func TestCreateIssueReturnsIssueOutsideProject(t *testing.T) {
+	tests := []struct {
+		name           string
+		atomic         bool
+		wantRolledBack []string
+	}{
+		{name: "default", atomic: false},
+		{name: "atomic", atomic: true, wantRolledBack: []string{"deleted issue acme/pm#1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := fake.New("me")
+			backend.AddOrganization("acme", "Acme")
+			backend.AddRepo("acme", "pm")
+			backend.AddProject("acme", 1, "Roadmap")
+			cfg := &project.Config{OwnerType: project.OwnerTypeOrg, Owner: "acme", ProjectID: "1", DefaultRepo: "pm"}
+			service := project.New(&unassignableClient{Client: backend}, cfg, nil)
+
+			result, err := service.CreateIssue(context.Background(), project.CreateIssueParams{
+				Type:  "task",
+				Title: "Add login endpoint",
+				Fields: map[string]string{
+					"description":         "POST /login",
+					"checklist":           "- [ ] Handler",
+					"acceptance_criteria": "Returns a token",
+				},
+				Atomic: tt.atomic,
+			})
+			if err == nil {
+				t.Fatal("CreateIssue succeeded without adding the issue to the project")
+			}
+			if result == nil {
+				t.Fatalf("CreateIssue returned no result with %v", err)
+			}
+			if want := (project.IssueRef{Owner: "acme", Repo: "pm", Number: 1}); result.Ref != want || result.Issue.URL == "" {
+				t.Errorf("Ref = %v, URL = %q, want %v and its URL", result.Ref, result.Issue.URL, want)
+			}
+
+			var rolledBack []string
+			for _, step := range result.RolledBack {
+				rolledBack = append(rolledBack, step.Description)
+			}
+			if !slices.Equal(rolledBack, tt.wantRolledBack) {
+				t.Errorf("rolled back %q, want %q", rolledBack, tt.wantRolledBack)
+			}
+		})
+	}
+}
+
+func TestApplyAddsIssueLeftOutsideProject(t *testing.T) {
+	backend := fake.New("me")
+	backend.AddOrganization("acme", "Acme")
+	backend.AddRepo("acme", "pm")
+	roadmap := backend.AddProject("acme", 1, "Roadmap")
+	cfg := &project.Config{OwnerType: project.OwnerTypeOrg, Owner: "acme", ProjectID: "1", DefaultRepo: "pm"}
+
+	plan, err := project.ParsePlan([]byte(`
+epic:
+  key: login
+  type: task
+  title: Add login endpoint
+  fields:
+    description: POST /login
+    checklist: "- [ ] Handler"
+    acceptance_criteria: Returns a token
+`))
+	if err != nil {
+		t.Fatalf("ParsePlan: %v", err)
+	}
+
+	// The first apply creates the issue but stops before it reaches the project
+	result, err := project.New(&unassignableClient{Client: backend}, cfg, nil).Apply(context.Background(), plan)
+	if err == nil {
+		t.Fatal("Apply succeeded without adding the issue to the project")
+	}
+	if len(result.Issues) != 1 || !result.Issues[0].Created {
+		t.Fatalf("first apply reported %+v, want the created issue", result.Issues)
+	}
+
+	result, err = project.New(backend, cfg, nil).Apply(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("Apply again: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Created {
+		t.Fatalf("second apply reported %+v, want the existing issue", result.Issues)
+	}
+	if want := (project.IssueRef{Owner: "acme", Repo: "pm", Number: 1}); result.Issues[0].Ref != want {
+		t.Errorf("Ref = %v, want %v", result.Issues[0].Ref, want)
+	}
+	if _, ok := backend.Issue("acme", "pm", 2); ok {
+		t.Errorf("second apply created a duplicate issue")
+	}
+
+	if len(roadmap.Items) != 1 {
+		t.Errorf("project has %d items, want 1", len(roadmap.Items))
+	}
+}
@@ -0,0 +1,95 @@
+package project
+
+import (
+	"context"
+	"fmt"
+)
+
+// RolledBackStep is a completed step of an atomic CreateIssue that was undone
+// after a later step failed. Err is set if undoing it failed too.
+type RolledBackStep struct {
+	Description string // What was undone, e.g. "removed link of acme/pm#5 to parent acme/pm#4"
+	Err         error
+}
+
+// undoLog records how to undo each completed step of an atomic CreateIssue.
+// A nil log records nothing, so non-atomic callers pass nil.
+type undoLog struct {
+	steps []undoStep
+}
+
+type undoStep struct {
+	description string
+	// undo reverts the step. It may return a description of what it did
+	// instead, or "" to keep description.
+	undo func(ctx context.Context) (string, error)
+}
+
+func (l *undoLog) add(description string, undo func(ctx context.Context) (string, error)) {
+	if l == nil {
+		return
+	}
+	l.steps = append(l.steps, undoStep{description: description, undo: undo})
+}
+
+// issueCreated records the created issue, which is deleted on rollback or,
+// without admin permission to delete it, closed as not planned
+func (l *undoLog) issueCreated(s *Service, result *CreateIssueResult) {
+	l.add(fmt.Sprintf("deleted issue %s", result.Ref), func(ctx context.Context) (string, error) {
+		err := s.Client.DeleteIssue(ctx, result.Issue.ID)
+		if err == nil {
+			return "", nil
+		}
+		if closeErr := s.Client.CloseIssue(ctx, result.Issue.ID); closeErr != nil {
+			return fmt.Sprintf("could not delete or close issue %s", result.Ref), fmt.Errorf("%w; %w", err, closeErr)
+		}
+		return fmt.Sprintf("closed issue %s as not planned (could not delete it: %v)", result.Ref, err), nil
+	})
+}
+
+// addedToProject records the project item of the created issue. Removing the
+// item also drops the project fields set on it.
+func (l *undoLog) addedToProject(s *Service, result *CreateIssueResult) {
+	l.add(fmt.Sprintf("removed %s from the project", result.Ref), func(ctx context.Context) (string, error) {
+		projectNodeID, err := s.ProjectNodeID(ctx)
+		if err != nil {
+			return "", err
+		}
+		return "", s.Client.DeleteProjectItem(ctx, projectNodeID, result.ProjectItemID)
+	})
+}
+
+// linked records the link of child to parent
+func (l *undoLog) linked(s *Service, parent, child IssueRef) {
+	l.add(fmt.Sprintf("removed link of %s to parent %s", child, parent), func(ctx context.Context) (string, error) {
+		return "", s.Client.RemoveSubIssue(ctx, parent.Owner, parent.Repo, parent.Number, child.Number)
+	})
+}
+
+// blockedBy records the dependency of blocked on blocking
+func (l *undoLog) blockedBy(s *Service, blocked, blocking IssueRef) {
+	l.add(fmt.Sprintf("removed dependency of %s on %s", blocked, blocking), func(ctx context.Context) (string, error) {
+		return "", s.Client.RemoveBlockedBy(ctx, blocked.Owner, blocked.Repo, blocked.Number, blocking.Number)
+	})
+}
+
+// rollback undoes the steps in l in reverse order. It continues past steps
+// that can't be undone and reports each one.
+func (s *Service) rollback(ctx context.Context, ref IssueRef, l *undoLog) []RolledBackStep {
+	var rolledBack []RolledBackStep
+	for i := len(l.steps) - 1; i >= 0; i-- {
+		step := l.steps[i]
+		description, err := step.undo(ctx)
+		if description == "" {
+			description = step.description
+		}
+
+		rolledBack = append(rolledBack, RolledBackStep{Description: description, Err: err})
+		if err != nil {
+			s.emit(StepRollback, ref, err, "failed to undo: %s", description)
+		} else {
+			s.emit(StepRollback, ref, nil, "%s", description)
+		}
+	}
+	return rolledBack
+}